@@ -0,0 +1,195 @@
+package ison
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldTag is the parsed form of an `ison:"..."` struct tag used by Marshal
+// and Unmarshal, e.g. `ison:"user_id,ref=users"` or `ison:",block=users"`.
+type fieldTag struct {
+	name      string
+	skip      bool
+	omitempty bool
+	inline    bool
+	typeHint  string
+	ref       string
+	block     string
+}
+
+func parseFieldTag(defaultName, tag string) fieldTag {
+	if tag == "-" {
+		return fieldTag{skip: true}
+	}
+
+	parts := strings.Split(tag, ",")
+	ft := fieldTag{name: defaultName}
+	if len(parts) > 0 && parts[0] != "" {
+		ft.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			ft.omitempty = true
+		case opt == "inline":
+			ft.inline = true
+		case strings.HasPrefix(opt, "type="):
+			ft.typeHint = strings.TrimPrefix(opt, "type=")
+		case strings.HasPrefix(opt, "ref="):
+			ft.ref = strings.TrimPrefix(opt, "ref=")
+		case strings.HasPrefix(opt, "block="):
+			ft.block = strings.TrimPrefix(opt, "block=")
+		}
+	}
+	return ft
+}
+
+func toSnakeCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToLower(sb.String())
+}
+
+// codecField is one struct field's precomputed encoding/decoding info.
+// index is a field-index path (as accepted by reflect.Value.FieldByIndex),
+// rather than a single int, so an `inline` embedded struct's fields can be
+// addressed directly without re-walking the struct on every access.
+type codecField struct {
+	index     []int
+	name      string
+	typeHint  string
+	ref       string
+	omitempty bool
+}
+
+// structCodec is the cached, reflection-derived description of how to
+// encode and decode a struct type's fields as an ISON row. Codecs are built
+// once per type and cached in codecCache, so repeated Marshal/Unmarshal
+// calls on the same type don't re-walk the struct.
+type structCodec struct {
+	fields []codecField
+}
+
+var codecCache sync.Map // reflect.Type -> *structCodec
+
+func getCodec(t reflect.Type) *structCodec {
+	if cached, ok := codecCache.Load(t); ok {
+		return cached.(*structCodec)
+	}
+
+	codec := &structCodec{fields: collectFields(t, nil)}
+
+	actual, _ := codecCache.LoadOrStore(t, codec)
+	return actual.(*structCodec)
+}
+
+// collectFields walks t's fields, flattening any `inline` field's own
+// fields into the result under prefix so they're addressed as a single
+// index path into the outer struct.
+func collectFields(t reflect.Type, prefix []int) []codecField {
+	var fields []codecField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		tagStr, hasTag := f.Tag.Lookup("ison")
+		tag := parseFieldTag(toSnakeCase(f.Name), tagStr)
+		if !hasTag {
+			tag.name = toSnakeCase(f.Name)
+		}
+		if tag.skip || tag.block != "" {
+			continue
+		}
+
+		path := append(append([]int(nil), prefix...), i)
+
+		if tag.inline {
+			fields = append(fields, collectFields(derefType(f.Type), path)...)
+			continue
+		}
+
+		fields = append(fields, codecField{
+			index:     path,
+			name:      tag.name,
+			typeHint:  typeHintFor(f.Type, tag),
+			ref:       tag.ref,
+			omitempty: tag.omitempty,
+		})
+	}
+	return fields
+}
+
+func typeHintFor(t reflect.Type, tag fieldTag) string {
+	if tag.ref != "" {
+		return "ref"
+	}
+	if tag.typeHint != "" {
+		return tag.typeHint
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "bool"
+	default:
+		return ""
+	}
+}
+
+// blockField names a container struct's block-tagged field (e.g.
+// `ison:",block=users"`) and the struct field index that holds its data.
+type blockField struct {
+	name  string
+	index int
+}
+
+// blockFields returns t's block-tagged fields in struct declaration order,
+// so Marshal produces blocks in a stable, repeatable order.
+func blockFields(t reflect.Type) []blockField {
+	var fields []blockField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tagStr, hasTag := f.Tag.Lookup("ison")
+		if !hasTag {
+			continue
+		}
+		tag := parseFieldTag(toSnakeCase(f.Name), tagStr)
+		if tag.block != "" {
+			fields = append(fields, blockField{name: tag.block, index: i})
+		}
+	}
+	return fields
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func tableNameFor(t reflect.Type) string {
+	return toSnakeCase(derefType(t).Name()) + "s"
+}
+
+func objectNameFor(t reflect.Type) string {
+	return toSnakeCase(derefType(t).Name())
+}
@@ -0,0 +1,269 @@
+package ison
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Decoder reads ISON text block-by-block from an io.Reader, without
+// materializing the whole Document in memory. It mirrors ISONLStream's
+// streaming model, but operates on the multi-line block syntax handled
+// by Parser.parseBlock rather than ISONL's one-line-per-record format.
+type Decoder struct {
+	scanner *bufio.Scanner
+	line    string
+	hasLine bool
+	err     error
+	done    bool
+}
+
+// NewDecoder creates a Decoder that reads ISON blocks from r. r may be a
+// file, an HTTP response body, a pipe, or any other io.Reader.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{scanner: bufio.NewScanner(r)}
+}
+
+// nextLine returns the next line of input, buffering at most one line of
+// lookahead so block boundaries (a blank line or a new "kind.name" header)
+// can be detected without consuming the line that ends the current block.
+func (d *Decoder) nextLine() (string, bool) {
+	if d.hasLine {
+		d.hasLine = false
+		return d.line, true
+	}
+	if !d.scanner.Scan() {
+		return "", false
+	}
+	return strings.TrimRight(d.scanner.Text(), "\r"), true
+}
+
+func (d *Decoder) pushBack(line string) {
+	d.line = line
+	d.hasLine = true
+}
+
+// nextHeader scans past blank lines and comments to find the next block
+// header, returning its kind and name. It returns io.EOF once there are no
+// more blocks in the stream.
+func (d *Decoder) nextHeader() (kind, name string, err error) {
+	for {
+		line, ok := d.nextLine()
+		if !ok {
+			if err := d.scanner.Err(); err != nil {
+				return "", "", err
+			}
+			return "", "", io.EOF
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.Contains(trimmed, ".") && !strings.HasPrefix(trimmed, "\"") {
+			parts := strings.SplitN(trimmed, ".", 2)
+			if len(parts) == 2 && isValidKind(parts[0]) {
+				return parts[0], parts[1], nil
+			}
+		}
+	}
+}
+
+func (d *Decoder) readFields() []FieldInfo {
+	var fieldsLine string
+	for {
+		line, ok := d.nextLine()
+		if !ok {
+			return nil
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		fieldsLine = trimmed
+		break
+	}
+
+	fields := make([]FieldInfo, 0)
+	for _, field := range tokenizeLine(fieldsLine) {
+		fname, ftype := parseFieldDef(field)
+		fields = append(fields, FieldInfo{Name: fname, TypeHint: ftype})
+	}
+	return fields
+}
+
+// atBlockBoundary reports whether line ends the current block (blank line
+// or the start of a new block header). A header line is pushed back for the
+// next read; a blank line is consumed.
+func (d *Decoder) atBlockBoundary(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return true
+	}
+	if strings.Contains(trimmed, ".") && !strings.HasPrefix(trimmed, "\"") {
+		parts := strings.SplitN(trimmed, ".", 2)
+		if len(parts) == 2 && isValidKind(parts[0]) {
+			d.pushBack(line)
+			return true
+		}
+	}
+	return false
+}
+
+// NextBlock reads and returns the next block from the stream in full,
+// including its rows. It returns io.EOF once there are no more blocks.
+func (d *Decoder) NextBlock() (*Block, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	kind, name, err := d.nextHeader()
+	if err != nil {
+		if err == io.EOF {
+			d.done = true
+		} else {
+			d.err = err
+		}
+		return nil, err
+	}
+
+	block := NewBlock(kind, name)
+	block.Fields = d.readFields()
+
+	inSummary := false
+	for {
+		line, ok := d.nextLine()
+		if !ok {
+			break
+		}
+		if d.atBlockBoundary(line) {
+			break
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if trimmed == "---" {
+			inSummary = true
+			continue
+		}
+
+		row := rowFromTokens(block.Fields, tokenizeLine(trimmed))
+		if inSummary {
+			block.SummaryRow = row
+		} else {
+			block.AddRow(row)
+		}
+	}
+
+	return block, nil
+}
+
+func rowFromTokens(fields []FieldInfo, tokens []string) Row {
+	row := make(Row)
+	for i, token := range tokens {
+		if i < len(fields) {
+			row[fields[i].Name] = parseValue(token, fields[i].TypeHint)
+		}
+	}
+	return row
+}
+
+// NextTable scans forward to the next "table" block, fully consuming and
+// discarding any non-table ("object" or "meta") blocks along the way, and
+// returns a RowIterator over its rows without materializing Block.Rows. It
+// returns io.EOF once there are no more table blocks in the stream.
+func (d *Decoder) NextTable() (name string, it *RowIterator, err error) {
+	for {
+		kind, blockName, err := d.nextHeader()
+		if err != nil {
+			if err == io.EOF {
+				d.done = true
+			} else {
+				d.err = err
+			}
+			return "", nil, err
+		}
+
+		fields := d.readFields()
+		if kind != "table" {
+			d.discardBlockBody()
+			continue
+		}
+
+		return blockName, &RowIterator{dec: d, fields: fields}, nil
+	}
+}
+
+// discardBlockBody consumes lines up to the next block boundary without
+// interpreting them, used to skip past a non-table block.
+func (d *Decoder) discardBlockBody() {
+	for {
+		line, ok := d.nextLine()
+		if !ok {
+			return
+		}
+		if d.atBlockBoundary(line) {
+			return
+		}
+	}
+}
+
+// RowIterator yields the rows of a table block one at a time, reading them
+// lazily from the underlying Decoder so the full set of rows never needs to
+// be held in memory at once.
+type RowIterator struct {
+	dec    *Decoder
+	fields []FieldInfo
+	done   bool
+	err    error
+}
+
+// Fields returns the field definitions for the block being iterated.
+func (it *RowIterator) Fields() []FieldInfo {
+	return it.fields
+}
+
+// Next returns the next data row in the block. It returns io.EOF once the
+// block ends: a blank line, a "---" summary separator, a new block header,
+// or end of input.
+func (it *RowIterator) Next() (Row, error) {
+	if it.done {
+		return nil, io.EOF
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	for {
+		line, ok := it.dec.nextLine()
+		if !ok {
+			it.done = true
+			if err := it.dec.scanner.Err(); err != nil {
+				it.err = err
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" {
+			it.done = true
+			return nil, io.EOF
+		}
+		if it.dec.atBlockBoundary(line) {
+			it.done = true
+			return nil, io.EOF
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		return rowFromTokens(it.fields, tokenizeLine(trimmed)), nil
+	}
+}
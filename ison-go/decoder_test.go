@@ -0,0 +1,158 @@
+package ison
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderNextBlockReadsBlocksOneAtATime(t *testing.T) {
+	input := `
+table.users
+id name
+1 Alice
+2 Bob
+
+object.config
+debug:bool
+true
+`
+	dec := NewDecoder(strings.NewReader(input))
+
+	block, err := dec.NextBlock()
+	require.NoError(t, err)
+	assert.Equal(t, "table", block.Kind)
+	assert.Equal(t, "users", block.Name)
+	assert.Len(t, block.Rows, 2)
+
+	block, err = dec.NextBlock()
+	require.NoError(t, err)
+	assert.Equal(t, "object", block.Kind)
+	assert.Equal(t, "config", block.Name)
+	active, ok := block.Rows[0]["debug"].AsBool()
+	assert.True(t, ok)
+	assert.True(t, active)
+
+	_, err = dec.NextBlock()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestDecoderNextBlockCapturesSummaryRow(t *testing.T) {
+	input := `
+table.orders
+id total:float
+1 10.0
+2 20.0
+---
+~ 30.0
+`
+	dec := NewDecoder(strings.NewReader(input))
+
+	block, err := dec.NextBlock()
+	require.NoError(t, err)
+	require.NotNil(t, block.SummaryRow)
+	total, ok := block.SummaryRow["total"].AsFloat()
+	assert.True(t, ok)
+	assert.Equal(t, 30.0, total)
+}
+
+func TestDecoderMatchesParseOutput(t *testing.T) {
+	input := `
+table.users
+id:int name
+1 Alice
+2 Bob
+
+object.config
+debug:bool
+true
+`
+	want, err := Parse(input)
+	require.NoError(t, err)
+
+	dec := NewDecoder(strings.NewReader(input))
+	got := NewDocument()
+	for {
+		block, err := dec.NextBlock()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got.AddBlock(block)
+	}
+
+	assert.Equal(t, want.Order, got.Order)
+	for _, name := range want.Order {
+		wantBlock, _ := want.Get(name)
+		gotBlock, _ := got.Get(name)
+		assert.Equal(t, wantBlock.Rows, gotBlock.Rows)
+	}
+}
+
+func TestRowIteratorYieldsRowsWithoutMaterializingBlock(t *testing.T) {
+	input := `
+table.users
+id name
+1 Alice
+2 Bob
+3 Carol
+`
+	dec := NewDecoder(strings.NewReader(input))
+
+	name, it, err := dec.NextTable()
+	require.NoError(t, err)
+	assert.Equal(t, "users", name)
+	assert.Equal(t, []string{"id", "name"}, fieldNames(it.Fields()))
+
+	var got []Row
+	for {
+		row, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, row)
+	}
+	require.Len(t, got, 3)
+	rowName, _ := got[1]["name"].AsString()
+	assert.Equal(t, "Bob", rowName)
+
+	_, err = dec.NextBlock()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestNextTableSkipsNonTableBlocks(t *testing.T) {
+	input := `
+object.config
+debug:bool
+true
+
+table.users
+id name
+1 Alice
+`
+	dec := NewDecoder(strings.NewReader(input))
+
+	name, it, err := dec.NextTable()
+	require.NoError(t, err)
+	assert.Equal(t, "users", name)
+
+	row, err := it.Next()
+	require.NoError(t, err)
+	id, _ := row["id"].AsInt()
+	assert.Equal(t, int64(1), id)
+
+	_, err = it.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func fieldNames(fields []FieldInfo) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package ison
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ISONLWriter streams ISONL records to an underlying io.Writer one row at a
+// time, the write-side counterpart to ISONLStream and Decoder: producers
+// never have to hold a full Document in memory to emit a large ISONL
+// stream.
+type ISONLWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewISONLWriter creates an ISONLWriter over w.
+func NewISONLWriter(w io.Writer) *ISONLWriter {
+	return &ISONLWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteRecord writes one row of blockName as a single ISONL line, in the
+// same "table.<name>|<fields>|<values>" shape DumpsISONL produces. fields
+// describes the row's columns, repeated on every line the same way ISONL
+// is self-describing per record. Once WriteRecord or Flush returns an
+// error, every later call returns that same error without writing.
+func (enc *ISONLWriter) WriteRecord(blockName string, fields []FieldInfo, row Row) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	var header strings.Builder
+	for i, f := range fields {
+		if i > 0 {
+			header.WriteByte(' ')
+		}
+		if f.TypeHint != "" {
+			fmt.Fprintf(&header, "%s:%s", f.Name, f.TypeHint)
+		} else {
+			header.WriteString(f.Name)
+		}
+	}
+
+	if _, err := fmt.Fprintf(enc.w, "table.%s|%s|", blockName, header.String()); err != nil {
+		enc.err = err
+		return err
+	}
+	for i, f := range fields {
+		if i > 0 {
+			enc.w.WriteByte(' ')
+		}
+		if val, ok := row[f.Name]; ok {
+			enc.w.WriteString(val.ToISON())
+		} else {
+			enc.w.WriteByte('~')
+		}
+	}
+	if _, err := enc.w.WriteString("\n"); err != nil {
+		enc.err = err
+		return err
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (enc *ISONLWriter) Flush() error {
+	if enc.err != nil {
+		return enc.err
+	}
+	return enc.w.Flush()
+}
@@ -0,0 +1,58 @@
+package ison
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestISONLWriterWriteRecordRoundtripsThroughParseISONL(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewISONLWriter(&buf)
+
+	fields := []FieldInfo{{Name: "id", TypeHint: "int"}, {Name: "name", TypeHint: "string"}}
+	require.NoError(t, w.WriteRecord("users", fields, Row{"id": Int(1), "name": String("Alice")}))
+	require.NoError(t, w.WriteRecord("users", fields, Row{"id": Int(2), "name": String("Bob")}))
+	require.NoError(t, w.Flush())
+
+	doc, err := ParseISONL(buf.String())
+	require.NoError(t, err)
+
+	block, ok := doc.Get("users")
+	require.True(t, ok)
+	require.Len(t, block.Rows, 2)
+	name, _ := block.Rows[1]["name"].AsString()
+	assert.Equal(t, "Bob", name)
+}
+
+func TestISONLWriterMissingFieldWritesTilde(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewISONLWriter(&buf)
+
+	fields := []FieldInfo{{Name: "id", TypeHint: "int"}, {Name: "name", TypeHint: "string"}}
+	require.NoError(t, w.WriteRecord("users", fields, Row{"id": Int(1)}))
+	require.NoError(t, w.Flush())
+
+	assert.Contains(t, buf.String(), "1 ~")
+}
+
+func TestISONLWriterStopsAfterError(t *testing.T) {
+	w := NewISONLWriter(failingWriter{})
+
+	// bufio buffers small writes, so the underlying failure only surfaces
+	// once it's forced out by Flush.
+	_ = w.WriteRecord("users", []FieldInfo{{Name: "id"}}, Row{"id": Int(1)})
+	require.Error(t, w.Flush())
+
+	err := w.WriteRecord("users", []FieldInfo{{Name: "id"}}, Row{"id": Int(2)})
+	assert.Error(t, err)
+	assert.Error(t, w.Flush())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, assert.AnError
+}
@@ -0,0 +1,155 @@
+package ison
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// evalOperand evaluates a single operand of a predicate or sort expression
+// against row: a quoted string or numeric literal, a len(field) call, or a
+// bare field name looked up in row (Null() if absent).
+func evalOperand(s string, row Row) (Value, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "len(") && strings.HasSuffix(s, ")") {
+		inner, err := evalOperand(s[len("len("):len(s)-1], row)
+		if err != nil {
+			return Value{}, err
+		}
+		str, _ := inner.AsString()
+		return Int(int64(len(str))), nil
+	}
+
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return String(unquoted), nil
+		}
+		return String(s[1 : len(s)-1]), nil
+	}
+
+	if s == "true" {
+		return Bool(true), nil
+	}
+	if s == "false" {
+		return Bool(false), nil
+	}
+
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return Float(n), nil
+	}
+
+	if v, ok := row[s]; ok {
+		return v, nil
+	}
+	return Null(), nil
+}
+
+// comparisonOps lists the operators evalPredicate recognizes, longest first
+// so "==" isn't mistaken for a stray "=".
+var comparisonOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// indexTopLevel is strings.Index restricted to occurrences of substr outside
+// a double-quoted span of expr, so a quoted operand's own text (e.g. the
+// "==" in `name!="x==y"`) can't be mistaken for the expression's operator.
+// Quoting follows evalOperand's own model: a backslash escapes the next
+// character, otherwise an unescaped '"' toggles quote state.
+func indexTopLevel(expr, substr string) int {
+	inQuote := false
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && inQuote:
+			i++
+		case expr[i] == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case strings.HasPrefix(expr[i:], substr):
+			return i
+		}
+	}
+	return -1
+}
+
+// evalPredicate evaluates a tiny predicate expression, e.g. `age >= 18` or
+// `len(title) > 16`, against row. It supports exactly one comparison; there
+// is no boolean and/or composition, keeping views self-contained without
+// growing a full expression grammar.
+func evalPredicate(expr string, row Row) (bool, error) {
+	expr = strings.TrimSpace(expr)
+
+	for _, op := range comparisonOps {
+		idx := indexTopLevel(expr, op)
+		if idx < 0 {
+			continue
+		}
+		left, err := evalOperand(expr[:idx], row)
+		if err != nil {
+			return false, err
+		}
+		right, err := evalOperand(expr[idx+len(op):], row)
+		if err != nil {
+			return false, err
+		}
+		return compareValues(left, right, op)
+	}
+
+	return false, fmt.Errorf("ison: unrecognized predicate expression %q", expr)
+}
+
+// compareValues compares a and b numerically when both carry a numeric
+// reading, as booleans for "==" and "!=" when both are bools, and falls
+// back to a string comparison otherwise.
+func compareValues(a, b Value, op string) (bool, error) {
+	if af, aok := a.AsFloat(); aok {
+		if bf, bok := b.AsFloat(); bok {
+			return compareOrdered(af, bf, op), nil
+		}
+	}
+	if ab, aok := a.AsBool(); aok {
+		if bb, bok := b.AsBool(); bok {
+			switch op {
+			case "==":
+				return ab == bb, nil
+			case "!=":
+				return ab != bb, nil
+			}
+		}
+	}
+
+	as, _ := a.AsString()
+	bs, _ := b.AsString()
+	return compareOrdered(as, bs, op), nil
+}
+
+func compareOrdered[T string | float64](a, b T, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	default:
+		return false
+	}
+}
+
+// parseSortExpr splits a sort key expression like "published_at desc" into
+// its field name and direction; direction defaults to ascending.
+func parseSortExpr(expr string) (field string, desc bool) {
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 0:
+		return "", false
+	case 1:
+		return fields[0], false
+	default:
+		return fields[0], strings.EqualFold(fields[len(fields)-1], "desc")
+	}
+}
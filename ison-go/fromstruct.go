@@ -0,0 +1,23 @@
+package ison
+
+// FromStruct builds a Document from v using `ison:"..."` struct tags, the
+// typed counterpart to FromDict for callers who'd otherwise have to
+// pre-convert their data into a map[string]interface{}. A slice of structs
+// becomes a table block; a single struct (or pointer to one) becomes an
+// object block; a container struct with `ison:",block=<name>"` fields
+// becomes a full multi-block Document, same as Marshal.
+func FromStruct(v interface{}) *Document {
+	doc, err := marshalDocument(v, DefaultMarshalOptions())
+	if err != nil {
+		return NewDocument()
+	}
+	return doc
+}
+
+// ToStruct decodes doc into v, the Document-based counterpart to Unmarshal.
+// v must be a pointer to a slice of structs, a struct whose fields are
+// tagged `ison:",block=<name>"`, or any other struct (decoded from the first
+// row of the block named for its type, or of the document's only block).
+func ToStruct(doc *Document, v interface{}) error {
+	return unmarshalDocument(doc, v)
+}
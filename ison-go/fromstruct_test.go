@@ -0,0 +1,81 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fromStructUser struct {
+	ID   int64  `ison:"id"`
+	Name string `ison:"name"`
+}
+
+func TestFromStructSliceProducesTableBlock(t *testing.T) {
+	doc := FromStruct([]fromStructUser{{ID: 1, Name: "Alice"}})
+
+	block, ok := doc.Get("from_struct_users")
+	require.True(t, ok)
+	assert.Equal(t, "table", block.Kind)
+	name, _ := block.Rows[0]["name"].AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+func TestToStructDecodesDocumentIntoSlice(t *testing.T) {
+	doc := FromStruct([]fromStructUser{{ID: 1, Name: "Alice"}})
+
+	var out []fromStructUser
+	require.NoError(t, ToStruct(doc, &out))
+	require.Len(t, out, 1)
+	assert.Equal(t, "Alice", out[0].Name)
+}
+
+type FromStructAddress struct {
+	City string `ison:"city"`
+	Zip  string `ison:"zip,omitempty"`
+}
+
+type fromStructPerson struct {
+	Name              string `ison:"name"`
+	FromStructAddress `ison:",inline"`
+}
+
+func TestFromStructInlineFlattensEmbeddedFields(t *testing.T) {
+	p := fromStructPerson{Name: "Alice", FromStructAddress: FromStructAddress{City: "London"}}
+	doc := FromStruct([]fromStructPerson{p})
+
+	block, ok := doc.Get("from_struct_persons")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"name", "city", "zip"}, block.GetFieldNames())
+
+	row := block.Rows[0]
+	city, _ := row["city"].AsString()
+	assert.Equal(t, "London", city)
+	assert.NotContains(t, row, "zip") // omitempty drops the zero-valued field
+}
+
+func TestToStructDecodesInlineEmbeddedFields(t *testing.T) {
+	p := fromStructPerson{Name: "Alice", FromStructAddress: FromStructAddress{City: "London", Zip: "SW1"}}
+	doc := FromStruct([]fromStructPerson{p})
+
+	var out []fromStructPerson
+	require.NoError(t, ToStruct(doc, &out))
+	require.Len(t, out, 1)
+	assert.Equal(t, "London", out[0].City)
+	assert.Equal(t, "SW1", out[0].Zip)
+}
+
+type fromStructOrder struct {
+	ID     int64  `ison:"id"`
+	UserID string `ison:"user_id,ref=from_struct_users"`
+}
+
+func TestFromStructRefFieldProducesReference(t *testing.T) {
+	doc := FromStruct([]fromStructOrder{{ID: 1, UserID: "1"}})
+
+	block, _ := doc.Get("from_struct_orders")
+	ref, ok := block.Rows[0]["user_id"].AsRef()
+	require.True(t, ok)
+	assert.Equal(t, "from_struct_users", ref.Namespace)
+}
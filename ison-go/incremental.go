@@ -0,0 +1,80 @@
+package ison
+
+import "sort"
+
+// IncrementalDocument builds a Document one row at a time, for producers
+// that only ever have a single record in hand: each block's field set is
+// inferred lazily from the rows written to it, rather than needing to be
+// declared up front via NewBlock/AddField.
+type IncrementalDocument struct {
+	doc *Document
+}
+
+// NewIncrementalDocument creates an empty IncrementalDocument.
+func NewIncrementalDocument() *IncrementalDocument {
+	return &IncrementalDocument{doc: NewDocument()}
+}
+
+// AppendRow appends row to the table block named blockName, creating it on
+// first use. Any field in row not yet on the block is appended to the
+// block's fields, in sorted key order.
+func (inc *IncrementalDocument) AppendRow(blockName string, row Row) {
+	block, ok := inc.doc.Get(blockName)
+	if !ok {
+		block = NewBlock("table", blockName)
+		inc.doc.AddBlock(block)
+	}
+
+	for _, name := range sortedRowKeys(row) {
+		if !blockHasField(block, name) {
+			block.AddField(name, "")
+		}
+	}
+	block.AddRow(row)
+}
+
+// Document returns the Document built so far. It is live: further
+// AppendRow/Merge calls keep mutating the same Document this returns.
+func (inc *IncrementalDocument) Document() *Document {
+	return inc.doc
+}
+
+// Merge concatenates other's rows into inc's Document, block by block.
+// Matching block names have their rows appended and field sets reconciled:
+// a field present in other but not yet on inc's block is appended to the
+// end. Blocks present only in other are added with the same kind, fields,
+// and rows. A SummaryRow in other is not copied.
+func (inc *IncrementalDocument) Merge(other *Document) {
+	for _, name := range other.Order {
+		src := other.Blocks[name]
+		dst, ok := inc.doc.Get(name)
+		if !ok {
+			dst = NewBlock(src.Kind, name)
+			inc.doc.AddBlock(dst)
+		}
+		for _, f := range src.Fields {
+			if !blockHasField(dst, f.Name) {
+				dst.AddField(f.Name, f.TypeHint)
+			}
+		}
+		dst.Rows = append(dst.Rows, src.Rows...)
+	}
+}
+
+func blockHasField(b *Block, name string) bool {
+	for _, f := range b.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedRowKeys(row Row) []string {
+	keys := make([]string, 0, len(row))
+	for k := range row {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
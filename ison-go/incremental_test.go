@@ -0,0 +1,80 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalDocumentAppendRowInfersFields(t *testing.T) {
+	inc := NewIncrementalDocument()
+	inc.AppendRow("events", Row{"id": Int(1), "kind": String("login")})
+	inc.AppendRow("events", Row{"id": Int(2), "kind": String("logout")})
+
+	block, ok := inc.Document().Get("events")
+	require.True(t, ok)
+	assert.Equal(t, []string{"id", "kind"}, block.GetFieldNames())
+	assert.Len(t, block.Rows, 2)
+}
+
+func TestIncrementalDocumentAppendRowAddsLateFields(t *testing.T) {
+	inc := NewIncrementalDocument()
+	inc.AppendRow("events", Row{"id": Int(1)})
+	inc.AppendRow("events", Row{"id": Int(2), "detail": String("extra")})
+
+	block, _ := inc.Document().Get("events")
+	assert.Equal(t, []string{"id", "detail"}, block.GetFieldNames())
+}
+
+func TestIncrementalDocumentMergeAppendsRowsForMatchingBlocks(t *testing.T) {
+	inc := NewIncrementalDocument()
+	inc.AppendRow("events", Row{"id": Int(1)})
+
+	other := NewDocument()
+	events := NewBlock("table", "events")
+	events.AddField("id", "int")
+	events.AddRow(Row{"id": Int(2)})
+	other.AddBlock(events)
+
+	inc.Merge(other)
+
+	block, _ := inc.Document().Get("events")
+	require.Len(t, block.Rows, 2)
+	id2, _ := block.Rows[1]["id"].AsInt()
+	assert.Equal(t, int64(2), id2)
+}
+
+func TestIncrementalDocumentMergeReconcilesFieldSets(t *testing.T) {
+	inc := NewIncrementalDocument()
+	inc.AppendRow("events", Row{"id": Int(1)})
+
+	other := NewDocument()
+	events := NewBlock("table", "events")
+	events.AddField("id", "int")
+	events.AddField("detail", "string")
+	events.AddRow(Row{"id": Int(2), "detail": String("extra")})
+	other.AddBlock(events)
+
+	inc.Merge(other)
+
+	block, _ := inc.Document().Get("events")
+	assert.Equal(t, []string{"id", "detail"}, block.GetFieldNames())
+}
+
+func TestIncrementalDocumentMergeAddsNewBlocks(t *testing.T) {
+	inc := NewIncrementalDocument()
+
+	other := NewDocument()
+	config := NewBlock("object", "config")
+	config.AddField("debug", "bool")
+	config.AddRow(Row{"debug": Bool(true)})
+	other.AddBlock(config)
+
+	inc.Merge(other)
+
+	block, ok := inc.Document().Get("config")
+	require.True(t, ok)
+	assert.Equal(t, "object", block.Kind)
+	assert.Len(t, block.Rows, 1)
+}
@@ -9,9 +9,9 @@ import (
 	"io"
 	"os"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // Version is the current version of the ison-go package
@@ -27,6 +27,7 @@ const (
 	TypeFloat
 	TypeString
 	TypeReference
+	TypeSemver
 )
 
 // Reference represents an ISON reference (e.g., :1, :user:42, :OWNS:5)
@@ -74,7 +75,8 @@ func (r Reference) MarshalJSON() ([]byte, error) {
 	})
 }
 
-// Value represents an ISON value which can be null, bool, int, float, string, or reference
+// Value represents an ISON value which can be null, bool, int, float,
+// string, reference, or semver
 type Value struct {
 	Type      ValueType
 	BoolVal   bool
@@ -82,6 +84,12 @@ type Value struct {
 	FloatVal  float64
 	StringVal string
 	RefVal    Reference
+	SemverVal Semver
+
+	// queryMiss marks a Value returned by Get/Query for a path that didn't
+	// resolve, distinguishing "not found" from an explicit Null() (which
+	// leaves this false, its zero value). Unexported: only path.go sets it.
+	queryMiss bool
 }
 
 // Null creates a null Value
@@ -114,11 +122,23 @@ func Ref(r Reference) Value {
 	return Value{Type: TypeReference, RefVal: r}
 }
 
+// SemverValue creates a semantic-version Value.
+func SemverValue(s Semver) Value {
+	return Value{Type: TypeSemver, SemverVal: s}
+}
+
 // IsNull returns true if the value is null
 func (v Value) IsNull() bool {
 	return v.Type == TypeNull
 }
 
+// Exists reports whether v was actually found by Get/Query, as opposed to
+// being the zero Value returned on a miss. Values built any other way
+// (Null(), parsing, Marshal, ...) always report true.
+func (v Value) Exists() bool {
+	return !v.queryMiss
+}
+
 // AsBool returns the boolean value
 func (v Value) AsBool() (bool, bool) {
 	if v.Type == TypeBool {
@@ -162,6 +182,14 @@ func (v Value) AsRef() (Reference, bool) {
 	return Reference{}, false
 }
 
+// AsSemver returns the semver value
+func (v Value) AsSemver() (Semver, bool) {
+	if v.Type == TypeSemver {
+		return v.SemverVal, true
+	}
+	return Semver{}, false
+}
+
 // Interface returns the Go interface{} representation of the value
 func (v Value) Interface() interface{} {
 	switch v.Type {
@@ -177,6 +205,8 @@ func (v Value) Interface() interface{} {
 		return v.StringVal
 	case TypeReference:
 		return v.RefVal
+	case TypeSemver:
+		return v.SemverVal.String()
 	default:
 		return nil
 	}
@@ -197,7 +227,7 @@ func (v Value) ToISON() string {
 	case TypeFloat:
 		return strconv.FormatFloat(v.FloatVal, 'f', -1, 64)
 	case TypeString:
-		if strings.ContainsAny(v.StringVal, " \t\n\"") || v.StringVal == "" {
+		if needsISONQuoting(v.StringVal) || v.StringVal == "" || looksLikeNonString(v.StringVal) {
 			escaped := strings.ReplaceAll(v.StringVal, "\\", "\\\\")
 			escaped = strings.ReplaceAll(escaped, "\"", "\\\"")
 			escaped = strings.ReplaceAll(escaped, "\n", "\\n")
@@ -207,6 +237,8 @@ func (v Value) ToISON() string {
 		return v.StringVal
 	case TypeReference:
 		return v.RefVal.ToISON()
+	case TypeSemver:
+		return v.SemverVal.String()
 	default:
 		return "~"
 	}
@@ -217,6 +249,42 @@ func (v Value) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v.Interface())
 }
 
+// looksLikeNonString reports whether an unquoted token would be misread on
+// re-parse: as a bool/null literal, a reference, a number, one of the
+// line-level markers ("#" comments, "---" summary separators) that
+// parseBlock strips before tokenizing even reaches it, or — if the string
+// is the sole token on its line — a new block header ("table.foo" and
+// friends). ToISON quotes any string matching this so a round trip through
+// Parse always gets the same Value back.
+func looksLikeNonString(s string) bool {
+	switch s {
+	case "true", "TRUE", "false", "FALSE", "~", "null", "NULL", "---":
+		return true
+	}
+	if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "#") {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if kind, _, ok := strings.Cut(s, "."); ok && isValidKind(kind) {
+		return true
+	}
+	return false
+}
+
+// needsISONQuoting reports whether s contains a double quote or any
+// Unicode whitespace rune. Parser lines are trimmed with strings.TrimSpace
+// before tokenizing, which strips every such rune from a line's edges —
+// not just plain ' '/'\t'/'\n' — so any of them landing at the start or
+// end of an unquoted token would silently vanish on re-parse.
+func needsISONQuoting(s string) bool {
+	return strings.ContainsRune(s, '"') || strings.IndexFunc(s, unicode.IsSpace) >= 0
+}
+
 // FieldInfo represents information about a field/column in an ISON block
 type FieldInfo struct {
 	Name     string
@@ -307,13 +375,20 @@ func (b *Block) ToDict() map[string]interface{} {
 type Document struct {
 	Blocks map[string]*Block
 	Order  []string // Block names in order of appearance
+
+	// Views holds declarative derived-table pipelines (see view.go),
+	// materialized into concrete table Blocks by MaterializeViews.
+	Views     map[string]*View
+	ViewOrder []string // View names in order of appearance
 }
 
 // NewDocument creates a new empty Document
 func NewDocument() *Document {
 	return &Document{
-		Blocks: make(map[string]*Block),
-		Order:  []string{},
+		Blocks:    make(map[string]*Block),
+		Order:     []string{},
+		Views:     make(map[string]*View),
+		ViewOrder: []string{},
 	}
 }
 
@@ -325,6 +400,14 @@ func (d *Document) AddBlock(block *Block) {
 	d.Blocks[block.Name] = block
 }
 
+// AddView adds a view to the document.
+func (d *Document) AddView(v *View) {
+	if _, exists := d.Views[v.Name]; !exists {
+		d.ViewOrder = append(d.ViewOrder, v.Name)
+	}
+	d.Views[v.Name] = v
+}
+
 // Get returns a block by name
 func (d *Document) Get(name string) (*Block, bool) {
 	block, ok := d.Blocks[name]
@@ -389,8 +472,10 @@ func Load(path string) (*Document, error) {
 
 // DumpsOptions configures serialization behavior
 type DumpsOptions struct {
-	AlignColumns bool   // Pad columns for visual alignment
-	Delimiter    string // Column separator (default: " ")
+	AlignColumns bool       // Pad columns for visual alignment
+	Delimiter    string     // Column separator (default: " ")
+	FieldOrder   FieldOrder // Field ordering within each block (default: Block.Fields order)
+	BlockOrder   BlockOrder // Block ordering within the document (default: Document.Order)
 }
 
 // DefaultDumpsOptions returns default serialization options
@@ -454,7 +539,7 @@ func (p *Parser) parse() (*Document, error) {
 }
 
 func isValidKind(kind string) bool {
-	return kind == "table" || kind == "object" || kind == "meta"
+	return kind == "table" || kind == "object" || kind == "meta" || kind == "schema"
 }
 
 func (p *Parser) parseBlock(kind, name string) (*Block, error) {
@@ -545,6 +630,31 @@ func parseFieldDef(field string) (name, typeHint string) {
 	return field, ""
 }
 
+// formatFieldName quotes and escapes name the same way string values are
+// quoted (see Value.ToISON) whenever it's empty, contains whitespace or a
+// quote character, or starts with "#" — anything that would otherwise make
+// the serialized header re-tokenize into a different number of fields, or
+// vanish entirely as a comment line, when re-parsed.
+func formatFieldName(name string) string {
+	if name == "" || needsISONQuoting(name) || strings.HasPrefix(name, "#") {
+		escaped := strings.ReplaceAll(name, "\\", "\\\\")
+		escaped = strings.ReplaceAll(escaped, "\"", "\\\"")
+		escaped = strings.ReplaceAll(escaped, "\n", "\\n")
+		escaped = strings.ReplaceAll(escaped, "\t", "\\t")
+		return fmt.Sprintf("\"%s\"", escaped)
+	}
+	return name
+}
+
+// formatFieldHeader renders one field-header token ("name" or
+// "name:typeHint"), quoting either half as formatFieldName requires.
+func formatFieldHeader(f FieldInfo) string {
+	if f.TypeHint != "" {
+		return fmt.Sprintf("%s:%s", formatFieldName(f.Name), formatFieldName(f.TypeHint))
+	}
+	return formatFieldName(f.Name)
+}
+
 func tokenizeLine(line string) []string {
 	tokens := []string{}
 	current := strings.Builder{}
@@ -645,6 +755,11 @@ func parseValue(token string, typeHint string) Value {
 			return Ref(parseReference(token))
 		}
 		return String(token)
+	case "semver":
+		if sv, err := ParseSemver(token); err == nil {
+			return SemverValue(sv)
+		}
+		return String(token)
 	}
 
 	// Auto-inference
@@ -707,7 +822,9 @@ func DumpsWithOptions(doc *Document, opts DumpsOptions) string {
 		delim = " "
 	}
 
-	for i, name := range doc.Order {
+	names := sortedBlockNames(doc.Order, opts.BlockOrder)
+
+	for i, name := range names {
 		if i > 0 {
 			sb.WriteString("\n")
 		}
@@ -715,22 +832,23 @@ func DumpsWithOptions(doc *Document, opts DumpsOptions) string {
 		block := doc.Blocks[name]
 		sb.WriteString(fmt.Sprintf("%s.%s\n", block.Kind, block.Name))
 
+		fields := make([]FieldInfo, 0, len(block.Fields))
+		RangeFields(block, opts.FieldOrder, func(f FieldInfo) {
+			fields = append(fields, f)
+		})
+
 		// Write field headers
-		for j, field := range block.Fields {
+		for j, field := range fields {
 			if j > 0 {
 				sb.WriteString(delim)
 			}
-			if field.TypeHint != "" {
-				sb.WriteString(fmt.Sprintf("%s:%s", field.Name, field.TypeHint))
-			} else {
-				sb.WriteString(field.Name)
-			}
+			sb.WriteString(formatFieldHeader(field))
 		}
 		sb.WriteString("\n")
 
 		// Calculate column widths for alignment
-		widths := make([]int, len(block.Fields))
-		for i, field := range block.Fields {
+		widths := make([]int, len(fields))
+		for i, field := range fields {
 			w := len(field.Name)
 			if field.TypeHint != "" {
 				w += len(field.TypeHint) + 1
@@ -738,7 +856,7 @@ func DumpsWithOptions(doc *Document, opts DumpsOptions) string {
 			widths[i] = w
 		}
 		for _, row := range block.Rows {
-			for i, field := range block.Fields {
+			for i, field := range fields {
 				if val, ok := row[field.Name]; ok {
 					w := len(val.ToISON())
 					if w > widths[i] {
@@ -750,7 +868,7 @@ func DumpsWithOptions(doc *Document, opts DumpsOptions) string {
 
 		// Write rows
 		for _, row := range block.Rows {
-			for j, field := range block.Fields {
+			for j, field := range fields {
 				if j > 0 {
 					sb.WriteString(delim)
 				}
@@ -766,7 +884,7 @@ func DumpsWithOptions(doc *Document, opts DumpsOptions) string {
 		// Write summary if present
 		if block.SummaryRow != nil {
 			sb.WriteString("---\n")
-			for j, field := range block.Fields {
+			for j, field := range fields {
 				if j > 0 {
 					sb.WriteString(delim)
 				}
@@ -803,11 +921,7 @@ func DumpsISONL(doc *Document) string {
 			if i > 0 {
 				fieldHeader.WriteString(" ")
 			}
-			if field.TypeHint != "" {
-				fieldHeader.WriteString(fmt.Sprintf("%s:%s", field.Name, field.TypeHint))
-			} else {
-				fieldHeader.WriteString(field.Name)
-			}
+			fieldHeader.WriteString(formatFieldHeader(field))
 		}
 		fields := fieldHeader.String()
 
@@ -1020,7 +1134,7 @@ func FromJSON(jsonText string) (*Document, error) {
 				if rowData, ok := item.(map[string]interface{}); ok {
 					row := make(Row)
 					for key, val := range rowData {
-						row[key] = interfaceToValue(val)
+						row[key] = interfaceToValue(val, false)
 					}
 					block.AddRow(row)
 				}
@@ -1036,7 +1150,7 @@ func FromJSON(jsonText string) (*Document, error) {
 			}
 			row := make(Row)
 			for key, val := range v {
-				row[key] = interfaceToValue(val)
+				row[key] = interfaceToValue(val, false)
 			}
 			block.AddRow(row)
 			doc.AddBlock(block)
@@ -1048,49 +1162,19 @@ func FromJSON(jsonText string) (*Document, error) {
 
 // FromDictOptions configures FromDict behavior
 type FromDictOptions struct {
-	AutoRefs   bool // Auto-detect and convert foreign keys to References
-	SmartOrder bool // Reorder columns for optimal LLM comprehension
+	AutoRefs     bool       // Auto-detect and convert foreign keys to References
+	DetectSemver bool       // Auto-detect and promote semver-shaped strings to Semver values
+	FieldOrder   FieldOrder // Column ordering within each block (default: first-seen order)
+	BlockOrder   BlockOrder // Block ordering within the document (default: alphabetical)
 }
 
 // DefaultFromDictOptions returns default FromDict options
 func DefaultFromDictOptions() FromDictOptions {
 	return FromDictOptions{
-		AutoRefs:   false,
-		SmartOrder: false,
+		AutoRefs: false,
 	}
 }
 
-// smartOrderFields reorders fields for optimal LLM comprehension
-// Order priority: id first, then names, then data, then references
-func smartOrderFields(fields []string) []string {
-	priorityNames := map[string]bool{
-		"name": true, "title": true, "label": true,
-		"description": true, "display_name": true, "full_name": true,
-	}
-
-	var idFields, nameFields, refFields, otherFields []string
-
-	for _, field := range fields {
-		fieldLower := strings.ToLower(field)
-		if fieldLower == "id" {
-			idFields = append(idFields, field)
-		} else if priorityNames[fieldLower] {
-			nameFields = append(nameFields, field)
-		} else if strings.HasSuffix(fieldLower, "_id") && fieldLower != "id" {
-			refFields = append(refFields, field)
-		} else {
-			otherFields = append(otherFields, field)
-		}
-	}
-
-	result := make([]string, 0, len(fields))
-	result = append(result, idFields...)
-	result = append(result, nameFields...)
-	result = append(result, otherFields...)
-	result = append(result, refFields...)
-	return result
-}
-
 // FromDict creates an ISON Document from a map
 func FromDict(data map[string]interface{}) *Document {
 	return FromDictWithOptions(data, DefaultFromDictOptions())
@@ -1100,9 +1184,14 @@ func FromDict(data map[string]interface{}) *Document {
 func FromDictWithOptions(data map[string]interface{}, opts FromDictOptions) *Document {
 	doc := NewDocument()
 
-	// Collect all table names for reference detection
+	// Collect all table names for reference detection. "views" is a
+	// reserved key describing derived tables (see view.go) rather than a
+	// table of its own.
 	tableNames := make(map[string]bool)
 	for name := range data {
+		if name == "views" {
+			continue
+		}
 		tableNames[name] = true
 	}
 
@@ -1131,12 +1220,20 @@ func FromDictWithOptions(data map[string]interface{}, opts FromDictOptions) *Doc
 		}
 	}
 
-	// Sort table names for consistent ordering
+	// Order table names for consistent output; alphabetical unless the
+	// caller supplies its own BlockOrder.
 	names := make([]string, 0, len(data))
 	for name := range data {
+		if name == "views" {
+			continue
+		}
 		names = append(names, name)
 	}
-	sort.Strings(names)
+	blockOrder := opts.BlockOrder
+	if blockOrder == nil {
+		blockOrder = AlphabeticalBlockOrder
+	}
+	names = sortedBlockNames(names, blockOrder)
 
 	for _, name := range names {
 		content := data[name]
@@ -1159,9 +1256,20 @@ func FromDictWithOptions(data map[string]interface{}, opts FromDictOptions) *Doc
 						}
 					}
 
-					// Apply smart ordering if enabled
-					if opts.SmartOrder {
-						fieldOrder = smartOrderFields(fieldOrder)
+					// Apply the requested field ordering, folding in a
+					// ReferencesLast order derived from the auto_refs
+					// detector so foreign-key-shaped fields sort last.
+					fieldOrderCmp := opts.FieldOrder
+					if opts.AutoRefs && len(refFields) > 0 {
+						refsLast := ReferencesLast(refFields)
+						if fieldOrderCmp != nil {
+							fieldOrderCmp = Join(fieldOrderCmp, refsLast)
+						} else {
+							fieldOrderCmp = refsLast
+						}
+					}
+					if fieldOrderCmp != nil {
+						fieldOrder = sortedFieldNames(fieldOrder, fieldOrderCmp)
 					}
 
 					block := NewBlock("table", name)
@@ -1185,7 +1293,7 @@ func FromDictWithOptions(data map[string]interface{}, opts FromDictOptions) *Doc
 										}
 									}
 								}
-								row[key] = interfaceToValue(val)
+								row[key] = interfaceToValue(val, opts.DetectSemver)
 							}
 							block.AddRow(row)
 						}
@@ -1202,25 +1310,38 @@ func FromDictWithOptions(data map[string]interface{}, opts FromDictOptions) *Doc
 			for key := range v {
 				fields = append(fields, key)
 			}
-			if opts.SmartOrder {
-				fields = smartOrderFields(fields)
+			if opts.FieldOrder != nil {
+				fields = sortedFieldNames(fields, opts.FieldOrder)
 			}
 			for _, key := range fields {
 				block.AddField(key, "")
 			}
 			row := make(Row)
 			for key, val := range v {
-				row[key] = interfaceToValue(val)
+				row[key] = interfaceToValue(val, opts.DetectSemver)
 			}
 			block.AddRow(row)
 			doc.AddBlock(block)
 		}
 	}
 
+	if viewsData, ok := data["views"].(map[string]interface{}); ok {
+		viewNames := make([]string, 0, len(viewsData))
+		for name := range viewsData {
+			viewNames = append(viewNames, name)
+		}
+		viewNames = sortedBlockNames(viewNames, blockOrder)
+		for _, name := range viewNames {
+			if spec, ok := viewsData[name].(map[string]interface{}); ok {
+				doc.AddView(viewFromDict(name, spec))
+			}
+		}
+	}
+
 	return doc
 }
 
-func interfaceToValue(v interface{}) Value {
+func interfaceToValue(v interface{}, detectSemver bool) Value {
 	switch val := v.(type) {
 	case nil:
 		return Null()
@@ -1236,6 +1357,11 @@ func interfaceToValue(v interface{}) Value {
 	case int64:
 		return Int(val)
 	case string:
+		if detectSemver {
+			if sv, err := ParseSemver(val); err == nil {
+				return SemverValue(sv)
+			}
+		}
 		return String(val)
 	default:
 		return String(fmt.Sprintf("%v", val))
@@ -702,7 +702,7 @@ func TestFromDictWithAutoRefs(t *testing.T) {
 
 	opts := FromDictOptions{
 		AutoRefs:   true,
-		SmartOrder: true,
+		FieldOrder: SmartFieldOrder,
 	}
 	doc := FromDictWithOptions(data, opts)
 
@@ -715,9 +715,9 @@ func TestFromDictWithAutoRefs(t *testing.T) {
 	assert.Equal(t, "42", ref.ID)
 }
 
-func TestSmartOrderFields(t *testing.T) {
+func TestSmartFieldOrder(t *testing.T) {
 	fields := []string{"email", "customer_id", "name", "id", "status"}
-	ordered := smartOrderFields(fields)
+	ordered := sortedFieldNames(fields, SmartFieldOrder)
 
 	assert.Equal(t, "id", ordered[0])
 	assert.Equal(t, "name", ordered[1])
@@ -733,5 +733,5 @@ func TestDefaultDumpsOptions(t *testing.T) {
 func TestDefaultFromDictOptions(t *testing.T) {
 	opts := DefaultFromDictOptions()
 	assert.False(t, opts.AutoRefs)
-	assert.False(t, opts.SmartOrder)
+	assert.Nil(t, opts.FieldOrder)
 }
@@ -0,0 +1,93 @@
+package ison
+
+import "fmt"
+
+// joinStep is one Query.Join/LeftJoin stage: merge, into every row, the
+// fields of the row in table that the Reference held in on points at.
+type joinStep struct {
+	table string
+	on    string // field holding the reference; "" infers it from table's namespace
+	left  bool   // keep rows whose reference doesn't resolve, instead of dropping them
+}
+
+// apply runs the join against rows, returning the augmented field list (the
+// other table's fields added as "<table>.<field>") and rows.
+func (j joinStep) apply(doc *Document, fields []string, rows []Row) ([]string, []Row, error) {
+	other, ok := doc.Get(j.table)
+	if !ok {
+		return nil, nil, fmt.Errorf("ison: join table %q not found", j.table)
+	}
+
+	byID := make(map[string]Row, len(other.Rows))
+	for _, row := range other.Rows {
+		if id, ok := row["id"]; ok {
+			byID[valueKey(id)] = row
+		}
+	}
+
+	onField := j.on
+	if onField == "" {
+		onField = inferJoinField(rows, j.table)
+		if onField == "" {
+			return nil, nil, fmt.Errorf("ison: could not infer a reference field for join on %q", j.table)
+		}
+	}
+
+	joinedFields := append([]string(nil), fields...)
+	for _, f := range other.GetFieldNames() {
+		if f == "id" {
+			continue
+		}
+		joinedFields = append(joinedFields, j.table+"."+f)
+	}
+
+	joined := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		match, ok := lookupJoinRow(row, onField, byID)
+		if !ok && !j.left {
+			continue
+		}
+		merged := make(Row, len(row))
+		for k, v := range row {
+			merged[k] = v
+		}
+		if ok {
+			for k, v := range match {
+				if k == "id" {
+					continue
+				}
+				merged[j.table+"."+k] = v
+			}
+		}
+		joined = append(joined, merged)
+	}
+
+	return joinedFields, joined, nil
+}
+
+func lookupJoinRow(row Row, onField string, byID map[string]Row) (Row, bool) {
+	v, ok := row[onField]
+	if !ok {
+		return nil, false
+	}
+	match, ok := byID[refKey(v)]
+	return match, ok
+}
+
+// inferJoinField finds the field in rows' first entry whose Reference
+// namespace names table (singular or plural), so Join/LeftJoin can be
+// called with an empty "on" when the reference field's own name doesn't
+// need spelling out.
+func inferJoinField(rows []Row, table string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	for field, v := range rows[0] {
+		if ref, ok := v.AsRef(); ok {
+			if ref.Namespace == table || ref.Namespace+"s" == table {
+				return field
+			}
+		}
+	}
+	return ""
+}
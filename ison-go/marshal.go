@@ -0,0 +1,221 @@
+package ison
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// MarshalOptions configures Marshal's behavior.
+type MarshalOptions struct {
+	// SmartOrder reorders each block's fields for optimal LLM comprehension
+	// by SmartFieldOrder, the same ordering FromDictWithOptions applies when
+	// its FieldOrder is SmartFieldOrder.
+	SmartOrder bool
+}
+
+// DefaultMarshalOptions returns default marshal options.
+func DefaultMarshalOptions() MarshalOptions {
+	return MarshalOptions{}
+}
+
+// Marshal encodes v as ISON text using `ison` struct tags, the way
+// encoding/json.Marshal uses `json` tags. A slice of structs encodes to a
+// single `table.<name>` block; a struct whose fields are tagged
+// `ison:",block=<name>"` encodes to a full multi-block Document, one block
+// per tagged field; any other struct encodes to a single `object.<name>`
+// block. Fields tagged `ref=<block>` encode as a Reference into that block.
+func Marshal(v interface{}) ([]byte, error) {
+	return MarshalWithOptions(v, DefaultMarshalOptions())
+}
+
+// MarshalWithOptions is Marshal with explicit options.
+func MarshalWithOptions(v interface{}, opts MarshalOptions) ([]byte, error) {
+	doc, err := marshalDocument(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(Dumps(doc)), nil
+}
+
+func marshalDocument(v interface{}, opts MarshalOptions) (*Document, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("ison: Marshal called with nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+
+	doc := NewDocument()
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		block, err := marshalSliceBlock(tableNameFor(rv.Type().Elem()), rv, opts)
+		if err != nil {
+			return nil, err
+		}
+		doc.AddBlock(block)
+		return doc, nil
+
+	case reflect.Struct:
+		t := rv.Type()
+		fields := blockFields(t)
+		if len(fields) == 0 {
+			block, err := marshalStructBlock(objectNameFor(t), rv, opts)
+			if err != nil {
+				return nil, err
+			}
+			doc.AddBlock(block)
+			return doc, nil
+		}
+
+		for _, bf := range fields {
+			fv := rv.Field(bf.index)
+			var block *Block
+			var err error
+			if fv.Kind() == reflect.Slice {
+				block, err = marshalSliceBlock(bf.name, fv, opts)
+			} else {
+				block, err = marshalStructBlock(bf.name, fv, opts)
+			}
+			if err != nil {
+				return nil, err
+			}
+			doc.AddBlock(block)
+		}
+		return doc, nil
+
+	default:
+		return nil, fmt.Errorf("ison: Marshal requires a struct or slice of structs, got %s", rv.Kind())
+	}
+}
+
+func marshalSliceBlock(name string, rv reflect.Value, opts MarshalOptions) (*Block, error) {
+	elemType := derefType(rv.Type().Elem())
+	codec := getCodec(elemType)
+
+	block := NewBlock("table", name)
+	for _, f := range orderedFields(codec, opts) {
+		block.AddField(f.name, f.typeHint)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		row, err := encodeRow(codec, derefValue(rv.Index(i)))
+		if err != nil {
+			return nil, err
+		}
+		block.AddRow(row)
+	}
+	return block, nil
+}
+
+func marshalStructBlock(name string, rv reflect.Value, opts MarshalOptions) (*Block, error) {
+	rv = derefValue(rv)
+	codec := getCodec(rv.Type())
+
+	block := NewBlock("object", name)
+	for _, f := range orderedFields(codec, opts) {
+		block.AddField(f.name, f.typeHint)
+	}
+
+	row, err := encodeRow(codec, rv)
+	if err != nil {
+		return nil, err
+	}
+	block.AddRow(row)
+	return block, nil
+}
+
+func orderedFields(codec *structCodec, opts MarshalOptions) []codecField {
+	if !opts.SmartOrder {
+		return codec.fields
+	}
+
+	names := make([]string, len(codec.fields))
+	byName := make(map[string]codecField, len(codec.fields))
+	for i, f := range codec.fields {
+		names[i] = f.name
+		byName[f.name] = f
+	}
+
+	ordered := make([]codecField, 0, len(codec.fields))
+	for _, name := range sortedFieldNames(names, SmartFieldOrder) {
+		ordered = append(ordered, byName[name])
+	}
+	return ordered
+}
+
+func derefValue(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+func encodeRow(codec *structCodec, rv reflect.Value) (Row, error) {
+	row := make(Row, len(codec.fields))
+	for _, f := range codec.fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitempty && fv.IsZero() {
+			continue
+		}
+		val, err := encodeFieldValue(fv, f)
+		if err != nil {
+			return nil, fmt.Errorf("ison: field %q: %w", f.name, err)
+		}
+		row[f.name] = val
+	}
+	return row, nil
+}
+
+func encodeFieldValue(fv reflect.Value, f codecField) (Value, error) {
+	if f.ref != "" {
+		if fv.Kind() == reflect.Ptr && fv.IsNil() {
+			return Null(), nil
+		}
+		return Ref(Reference{ID: fmt.Sprintf("%v", derefValue(fv).Interface()), Namespace: f.ref}), nil
+	}
+
+	if tm, ok := textMarshaler(fv); ok {
+		text, err := tm.MarshalText()
+		if err != nil {
+			return Value{}, err
+		}
+		return String(string(text)), nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return Null(), nil
+		}
+		return encodeFieldValue(fv.Elem(), f)
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return String(fv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int(fv.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		return Float(fv.Float()), nil
+	case reflect.Bool:
+		return Bool(fv.Bool()), nil
+	default:
+		return String(fmt.Sprintf("%v", fv.Interface())), nil
+	}
+}
+
+func textMarshaler(fv reflect.Value) (encoding.TextMarshaler, bool) {
+	if fv.CanInterface() {
+		if tm, ok := fv.Interface().(encoding.TextMarshaler); ok {
+			return tm, true
+		}
+	}
+	if fv.CanAddr() {
+		if tm, ok := fv.Addr().Interface().(encoding.TextMarshaler); ok {
+			return tm, true
+		}
+	}
+	return nil, false
+}
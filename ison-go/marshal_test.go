@@ -0,0 +1,181 @@
+package ison
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type marshalUser struct {
+	ID     int64  `ison:"id"`
+	Name   string `ison:"name"`
+	Secret string `ison:"-"`
+}
+
+func TestMarshalSliceProducesTableBlock(t *testing.T) {
+	users := []marshalUser{
+		{ID: 1, Name: "Alice", Secret: "hidden"},
+		{ID: 2, Name: "Bob", Secret: "hidden"},
+	}
+
+	data, err := Marshal(users)
+	require.NoError(t, err)
+
+	doc, err := Parse(string(data))
+	require.NoError(t, err)
+
+	block, ok := doc.Get("marshal_users")
+	require.True(t, ok)
+	assert.Equal(t, "table", block.Kind)
+	assert.Len(t, block.Rows, 2)
+	assert.NotContains(t, strings.Join(block.GetFieldNames(), ","), "secret")
+
+	name, _ := block.Rows[0]["name"].AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+type marshalOrder struct {
+	ID     int64  `ison:"id"`
+	UserID string `ison:"user_id,ref=marshal_users"`
+}
+
+func TestMarshalRefFieldProducesReference(t *testing.T) {
+	orders := []marshalOrder{{ID: 1, UserID: "42"}}
+
+	data, err := Marshal(orders)
+	require.NoError(t, err)
+
+	doc, err := Parse(string(data))
+	require.NoError(t, err)
+	block, _ := doc.Get("marshal_orders")
+	assert.Equal(t, "ref", block.Fields[1].TypeHint)
+
+	ref, ok := block.Rows[0]["user_id"].AsRef()
+	require.True(t, ok)
+	assert.Equal(t, "42", ref.ID)
+	assert.Equal(t, "marshal_users", ref.Namespace)
+}
+
+type marshalDoc struct {
+	Users  []marshalUser  `ison:",block=users"`
+	Orders []marshalOrder `ison:",block=orders"`
+}
+
+func TestMarshalContainerProducesMultiBlockDocument(t *testing.T) {
+	doc := marshalDoc{
+		Users:  []marshalUser{{ID: 1, Name: "Alice"}},
+		Orders: []marshalOrder{{ID: 1, UserID: "1"}},
+	}
+
+	data, err := Marshal(doc)
+	require.NoError(t, err)
+
+	parsed, err := Parse(string(data))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users", "orders"}, parsed.Order)
+}
+
+func TestUnmarshalSliceDecodesTableBlock(t *testing.T) {
+	input := `
+table.marshal_users
+id name
+1 Alice
+2 Bob
+`
+	var users []marshalUser
+	require.NoError(t, Unmarshal([]byte(input), &users))
+	require.Len(t, users, 2)
+	assert.Equal(t, int64(1), users[0].ID)
+	assert.Equal(t, "Alice", users[0].Name)
+}
+
+func TestUnmarshalRefFieldDecodesReferenceID(t *testing.T) {
+	input := `
+table.marshal_orders
+id user_id:ref
+1 :marshal_users:42
+`
+	var orders []marshalOrder
+	require.NoError(t, Unmarshal([]byte(input), &orders))
+	require.Len(t, orders, 1)
+	assert.Equal(t, "42", orders[0].UserID)
+}
+
+func TestUnmarshalContainerDecodesMultiBlockDocument(t *testing.T) {
+	input := `
+table.users
+id name
+1 Alice
+
+table.orders
+id user_id
+1 :1
+`
+	var doc marshalDoc
+	require.NoError(t, Unmarshal([]byte(input), &doc))
+	require.Len(t, doc.Users, 1)
+	require.Len(t, doc.Orders, 1)
+	assert.Equal(t, "Alice", doc.Users[0].Name)
+	assert.Equal(t, int64(1), doc.Orders[0].ID)
+}
+
+func TestMarshalSmartOrderReordersFields(t *testing.T) {
+	type smartRow struct {
+		CustomerID string `ison:"customer_id"`
+		Name       string `ison:"name"`
+		ID         int64  `ison:"id"`
+	}
+
+	data, err := MarshalWithOptions([]smartRow{{ID: 1, Name: "Ada", CustomerID: "c1"}}, MarshalOptions{SmartOrder: true})
+	require.NoError(t, err)
+
+	doc, err := Parse(string(data))
+	require.NoError(t, err)
+	block, _ := doc.Get("smart_rows")
+	assert.Equal(t, []string{"id", "name", "customer_id"}, block.GetFieldNames())
+}
+
+type marshalTimestamp struct {
+	At time.Time
+}
+
+func (m marshalTimestamp) MarshalText() ([]byte, error) {
+	return []byte(m.At.Format(time.RFC3339)), nil
+}
+
+func (m *marshalTimestamp) UnmarshalText(text []byte) error {
+	t, err := time.Parse(time.RFC3339, string(text))
+	if err != nil {
+		return err
+	}
+	m.At = t
+	return nil
+}
+
+type marshalEvent struct {
+	ID   int64            `ison:"id"`
+	When marshalTimestamp `ison:"when"`
+}
+
+func TestMarshalUnmarshalHonorTextMarshaler(t *testing.T) {
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []marshalEvent{{ID: 1, When: marshalTimestamp{At: when}}}
+
+	data, err := Marshal(events)
+	require.NoError(t, err)
+
+	var decoded []marshalEvent
+	require.NoError(t, Unmarshal(data, &decoded))
+	require.Len(t, decoded, 1)
+	assert.True(t, when.Equal(decoded[0].When.At))
+}
+
+func TestCodecCacheReusesStructCodec(t *testing.T) {
+	c1 := getCodec(reflect.TypeOf(marshalUser{}))
+	c2 := getCodec(reflect.TypeOf(marshalUser{}))
+	assert.Same(t, c1, c2)
+}
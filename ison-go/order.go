@@ -0,0 +1,192 @@
+package ison
+
+import "sort"
+
+// FieldOrder reports whether field a should sort before field b, the way a
+// comparator passed to sort.Slice would. Orderings are modeled on
+// protobuf's internal/order package: small, composable comparators rather
+// than one hardcoded heuristic.
+type FieldOrder func(a, b string) bool
+
+// BlockOrder reports whether block a should sort before block b.
+type BlockOrder func(a, b string) bool
+
+// AlphabeticalFieldOrder sorts fields lexicographically.
+func AlphabeticalFieldOrder(a, b string) bool {
+	return a < b
+}
+
+// AlphabeticalBlockOrder sorts blocks lexicographically.
+func AlphabeticalBlockOrder(a, b string) bool {
+	return a < b
+}
+
+// LegacyFieldOrder moves a field named "id" (case-insensitive) to the
+// front and otherwise leaves fields in their existing relative order.
+func LegacyFieldOrder(a, b string) bool {
+	aIsID := isIDField(a)
+	bIsID := isIDField(b)
+	if aIsID != bIsID {
+		return aIsID
+	}
+	return false
+}
+
+func isIDField(name string) bool {
+	return toLowerASCII(name) == "id"
+}
+
+func toLowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+var smartPriorityNames = map[string]bool{
+	"name": true, "title": true, "label": true,
+	"description": true, "display_name": true, "full_name": true,
+	"version": true,
+}
+
+// SmartFieldOrder reorders fields for optimal LLM comprehension: id first,
+// then human-readable name-like fields, then ordinary data fields, with
+// foreign-key-shaped "*_id" fields last. FromDictWithOptions applies it
+// when FromDictOptions.FieldOrder is set to SmartFieldOrder.
+func SmartFieldOrder(a, b string) bool {
+	rank := func(name string) int {
+		lower := toLowerASCII(name)
+		switch {
+		case lower == "id":
+			return 0
+		case smartPriorityNames[lower]:
+			return 1
+		case len(lower) > 3 && lower[len(lower)-3:] == "_id":
+			return 3
+		default:
+			return 2
+		}
+	}
+	return rank(a) < rank(b)
+}
+
+// InsertionFieldOrder returns a FieldOrder that preserves the relative
+// order fields appeared in original, so RangeFields/sortedFieldNames can
+// reproduce "however the caller built it" ordering even after round
+// tripping through a comparator-driven sort.
+func InsertionFieldOrder(original []string) FieldOrder {
+	index := make(map[string]int, len(original))
+	for i, name := range original {
+		index[name] = i
+	}
+	return func(a, b string) bool {
+		return index[a] < index[b]
+	}
+}
+
+// SchemaFieldOrder returns a FieldOrder that sorts fields according to
+// their position in schema; fields absent from schema sort after every
+// field that is present, in their existing relative order.
+func SchemaFieldOrder(schema []string) FieldOrder {
+	index := make(map[string]int, len(schema))
+	for i, name := range schema {
+		index[name] = i
+	}
+	return func(a, b string) bool {
+		ai, aok := index[a]
+		bi, bok := index[b]
+		if aok && bok {
+			return ai < bi
+		}
+		if aok != bok {
+			return aok
+		}
+		return false
+	}
+}
+
+// ReferencesLast returns a FieldOrder that sorts any field present in
+// refFields after every field that is not, letting the auto_refs detector
+// contribute its foreign-key findings as a composable ordering instead of
+// a one-off split-and-concat step.
+func ReferencesLast(refFields map[string]string) FieldOrder {
+	return func(a, b string) bool {
+		_, aRef := refFields[a]
+		_, bRef := refFields[b]
+		if aRef != bRef {
+			return !aRef
+		}
+		return false
+	}
+}
+
+// Join composes orders into a single FieldOrder: the first order that
+// considers a and b unequal (order(a,b) or order(b,a) holds) decides the
+// comparison; on a tie (neither holds) the next order is consulted. Orders
+// are consulted left to right.
+func Join(orders ...FieldOrder) FieldOrder {
+	return func(a, b string) bool {
+		for _, order := range orders {
+			if order == nil {
+				continue
+			}
+			if order(a, b) {
+				return true
+			}
+			if order(b, a) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// sortedFieldNames returns a copy of names sorted by order. A nil order
+// leaves names in their existing order.
+func sortedFieldNames(names []string, order FieldOrder) []string {
+	if order == nil {
+		return names
+	}
+	sorted := append([]string(nil), names...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// sortedBlockNames returns a copy of names sorted by order. A nil order
+// leaves names in their existing order.
+func sortedBlockNames(names []string, order BlockOrder) []string {
+	if order == nil {
+		return names
+	}
+	sorted := append([]string(nil), names...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// RangeFields walks block's fields in the order given by order, calling fn
+// for each. A nil order walks fields in block.Fields's existing order.
+func RangeFields(block *Block, order FieldOrder, fn func(FieldInfo)) {
+	if order == nil {
+		for _, f := range block.Fields {
+			fn(f)
+		}
+		return
+	}
+
+	byName := make(map[string]FieldInfo, len(block.Fields))
+	names := make([]string, len(block.Fields))
+	for i, f := range block.Fields {
+		byName[f.Name] = f
+		names[i] = f.Name
+	}
+	for _, name := range sortedFieldNames(names, order) {
+		fn(byName[name])
+	}
+}
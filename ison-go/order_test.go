@@ -0,0 +1,79 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlphabeticalFieldOrder(t *testing.T) {
+	ordered := sortedFieldNames([]string{"c", "a", "b"}, AlphabeticalFieldOrder)
+	assert.Equal(t, []string{"a", "b", "c"}, ordered)
+}
+
+func TestLegacyFieldOrderMovesIDFirstOnly(t *testing.T) {
+	ordered := sortedFieldNames([]string{"name", "email", "id"}, LegacyFieldOrder)
+	assert.Equal(t, []string{"id", "name", "email"}, ordered)
+}
+
+func TestInsertionFieldOrderPreservesOriginal(t *testing.T) {
+	original := []string{"b", "a", "c"}
+	ordered := sortedFieldNames([]string{"a", "b", "c"}, InsertionFieldOrder(original))
+	assert.Equal(t, original, ordered)
+}
+
+func TestSchemaFieldOrderFollowsSchemaThenLeftovers(t *testing.T) {
+	schema := []string{"id", "name"}
+	ordered := sortedFieldNames([]string{"email", "name", "id"}, SchemaFieldOrder(schema))
+	assert.Equal(t, []string{"id", "name", "email"}, ordered)
+}
+
+func TestReferencesLastOrder(t *testing.T) {
+	refFields := map[string]string{"customer_id": "customers"}
+	ordered := sortedFieldNames([]string{"customer_id", "id", "product"}, ReferencesLast(refFields))
+	assert.Equal(t, "customer_id", ordered[len(ordered)-1])
+}
+
+func TestJoinComposesOrdersLeftToRight(t *testing.T) {
+	refFields := map[string]string{"customer_id": "customers"}
+	order := Join(LegacyFieldOrder, ReferencesLast(refFields))
+
+	ordered := sortedFieldNames([]string{"customer_id", "name", "id"}, order)
+	assert.Equal(t, []string{"id", "name", "customer_id"}, ordered)
+}
+
+func TestRangeFieldsAppliesOrder(t *testing.T) {
+	block := NewBlock("table", "users")
+	block.AddField("name", "")
+	block.AddField("id", "")
+
+	var names []string
+	RangeFields(block, LegacyFieldOrder, func(f FieldInfo) {
+		names = append(names, f.Name)
+	})
+	assert.Equal(t, []string{"id", "name"}, names)
+}
+
+func TestRangeFieldsNilOrderPreservesBlockOrder(t *testing.T) {
+	block := NewBlock("table", "users")
+	block.AddField("name", "")
+	block.AddField("id", "")
+
+	var names []string
+	RangeFields(block, nil, func(f FieldInfo) {
+		names = append(names, f.Name)
+	})
+	assert.Equal(t, []string{"name", "id"}, names)
+}
+
+func TestDumpsWithOptionsFieldOrder(t *testing.T) {
+	doc := NewDocument()
+	block := NewBlock("table", "users")
+	block.AddField("name", "")
+	block.AddField("id", "")
+	block.AddRow(Row{"name": String("Alice"), "id": Int(1)})
+	doc.AddBlock(block)
+
+	out := DumpsWithOptions(doc, DumpsOptions{Delimiter: " ", FieldOrder: LegacyFieldOrder})
+	assert.Contains(t, out, "id name\n1 Alice\n")
+}
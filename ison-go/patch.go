@@ -0,0 +1,207 @@
+package ison
+
+import "fmt"
+
+// PatchOp is a single RFC 6902 JSON Patch operation. From is only used by
+// "move" and "copy"; Value is only used by "add", "replace", and "test".
+type PatchOp struct {
+	Op    string // "add", "remove", "replace", "move", "copy", "test"
+	Path  string // RFC 6901 JSON Pointer
+	From  string
+	Value interface{}
+}
+
+// ApplyPatch applies ops to doc in order, mutating it in place. Paths
+// target a block ("/users"), a row ("/users/0"), or a field
+// ("/users/0/name" or "/config/debug"); "-" in the row position means
+// "append" for "add". It stops at the first operation that fails and
+// returns its error, wrapped with that operation's Op and Path for context.
+func ApplyPatch(doc *Document, ops []PatchOp) error {
+	for _, op := range ops {
+		if err := applyPatchOp(doc, op); err != nil {
+			return fmt.Errorf("ison: patch op %q %q: %w", op.Op, op.Path, err)
+		}
+	}
+	return nil
+}
+
+func applyPatchOp(doc *Document, op PatchOp) error {
+	switch op.Op {
+	case "test":
+		v, err := resolveOrError(doc, op.Path)
+		if err != nil {
+			return err
+		}
+		want := interfaceToValue(op.Value, false)
+		if v.ToISON() != want.ToISON() {
+			return fmt.Errorf("test failed: %v != %v", v.Interface(), op.Value)
+		}
+		return nil
+
+	case "remove":
+		return removeAt(doc, op.Path)
+
+	case "add":
+		return addAt(doc, op.Path, op.Value)
+
+	case "replace":
+		if _, err := resolveOrError(doc, op.Path); err != nil {
+			return err
+		}
+		if err := removeAt(doc, op.Path); err != nil {
+			return err
+		}
+		return addAt(doc, op.Path, op.Value)
+
+	case "move":
+		v, err := resolveOrError(doc, op.From)
+		if err != nil {
+			return err
+		}
+		if err := removeAt(doc, op.From); err != nil {
+			return err
+		}
+		return addAt(doc, op.Path, v.Interface())
+
+	case "copy":
+		v, err := resolveOrError(doc, op.From)
+		if err != nil {
+			return err
+		}
+		return addAt(doc, op.Path, v.Interface())
+
+	default:
+		return fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+func resolveOrError(doc *Document, pointer string) (Value, error) {
+	v, ok := doc.Resolve(pointer)
+	if !ok {
+		return Value{}, fmt.Errorf("path %q not found", pointer)
+	}
+	return v, nil
+}
+
+// patchTarget is a JSON Pointer resolved down to where a patch op should
+// act: a field within a row (field != ""), a specific row (field == "" and
+// rowIndex >= 0), or a whole block (rowIndex < 0).
+type patchTarget struct {
+	block    *Block
+	rowIndex int
+	field    string
+}
+
+func locatePatchTarget(doc *Document, pointer string) (patchTarget, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return patchTarget{}, err
+	}
+	if len(tokens) == 0 {
+		return patchTarget{}, fmt.Errorf("cannot target the whole document")
+	}
+
+	block, ok := doc.Get(tokens[0])
+	if !ok {
+		return patchTarget{}, fmt.Errorf("block %q not found", tokens[0])
+	}
+	rest := tokens[1:]
+	if len(rest) == 0 {
+		return patchTarget{block: block, rowIndex: -1}, nil
+	}
+
+	if block.Kind == "object" {
+		if len(rest) != 1 {
+			return patchTarget{}, fmt.Errorf("invalid pointer into object block %q", block.Name)
+		}
+		return patchTarget{block: block, rowIndex: 0, field: rest[0]}, nil
+	}
+
+	idx := len(block.Rows)
+	if rest[0] != "-" {
+		n, err := parsePointerIndex(rest[0])
+		if err != nil {
+			return patchTarget{}, err
+		}
+		idx = n
+	}
+	field := ""
+	if len(rest) > 1 {
+		field = rest[1]
+	}
+	return patchTarget{block: block, rowIndex: idx, field: field}, nil
+}
+
+func parsePointerIndex(token string) (int, error) {
+	var n int
+	if _, err := fmt.Sscanf(token, "%d", &n); err != nil {
+		return 0, fmt.Errorf("invalid row index %q", token)
+	}
+	return n, nil
+}
+
+func addAt(doc *Document, pointer string, value interface{}) error {
+	target, err := locatePatchTarget(doc, pointer)
+	if err != nil {
+		return err
+	}
+
+	if target.field != "" {
+		if target.rowIndex < 0 || target.rowIndex >= len(target.block.Rows) {
+			return fmt.Errorf("row index out of range for %q", pointer)
+		}
+		row := target.block.Rows[target.rowIndex]
+		row[target.field] = interfaceToValue(value, false)
+
+		if !blockHasField(target.block, target.field) {
+			target.block.AddField(target.field, "")
+		}
+		return nil
+	}
+
+	rowData, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("add requires an object value for a row target, got %T", value)
+	}
+	row := make(Row, len(rowData))
+	for k, v := range rowData {
+		row[k] = interfaceToValue(v, false)
+	}
+
+	if target.rowIndex >= len(target.block.Rows) {
+		target.block.AddRow(row)
+		return nil
+	}
+	if target.rowIndex < 0 {
+		return fmt.Errorf("cannot add a row without an index")
+	}
+	target.block.Rows = append(target.block.Rows, Row{})
+	copy(target.block.Rows[target.rowIndex+1:], target.block.Rows[target.rowIndex:])
+	target.block.Rows[target.rowIndex] = row
+	return nil
+}
+
+func removeAt(doc *Document, pointer string) error {
+	target, err := locatePatchTarget(doc, pointer)
+	if err != nil {
+		return err
+	}
+
+	if target.field != "" {
+		if target.rowIndex < 0 || target.rowIndex >= len(target.block.Rows) {
+			return fmt.Errorf("row index out of range for %q", pointer)
+		}
+		row := target.block.Rows[target.rowIndex]
+		if _, ok := row[target.field]; !ok {
+			return fmt.Errorf("field %q not found", target.field)
+		}
+		delete(row, target.field)
+		return nil
+	}
+
+	if target.rowIndex < 0 || target.rowIndex >= len(target.block.Rows) {
+		return fmt.Errorf("row index out of range for %q", pointer)
+	}
+	target.block.Rows = append(target.block.Rows[:target.rowIndex], target.block.Rows[target.rowIndex+1:]...)
+	return nil
+}
@@ -0,0 +1,220 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patchTestDoc() *Document {
+	doc := NewDocument()
+
+	users := NewBlock("table", "users")
+	users.AddField("id", "int")
+	users.AddField("name", "string")
+	users.AddRow(Row{"id": Int(1), "name": String("Alice")})
+	users.AddRow(Row{"id": Int(2), "name": String("Bob")})
+	doc.AddBlock(users)
+
+	config := NewBlock("object", "config")
+	config.AddField("debug", "bool")
+	config.AddRow(Row{"debug": Bool(false)})
+	doc.AddBlock(config)
+
+	return doc
+}
+
+func TestResolveTableRowField(t *testing.T) {
+	doc := patchTestDoc()
+	v, ok := doc.Resolve("/users/0/name")
+	require.True(t, ok)
+	name, _ := v.AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+func TestResolveObjectBlockField(t *testing.T) {
+	doc := patchTestDoc()
+	v, ok := doc.Resolve("/config/debug")
+	require.True(t, ok)
+	b, _ := v.AsBool()
+	assert.False(t, b)
+}
+
+func TestResolveWholeRowRendersJSON(t *testing.T) {
+	doc := patchTestDoc()
+	v, ok := doc.Resolve("/users/0")
+	require.True(t, ok)
+	s, _ := v.AsString()
+	assert.Contains(t, s, "Alice")
+}
+
+func TestResolveWholeBlockRendersJSON(t *testing.T) {
+	doc := patchTestDoc()
+	v, ok := doc.Resolve("/users")
+	require.True(t, ok)
+	s, _ := v.AsString()
+	assert.Contains(t, s, "Alice")
+	assert.Contains(t, s, "Bob")
+}
+
+func TestResolveMissingPathMisses(t *testing.T) {
+	doc := patchTestDoc()
+	_, ok := doc.Resolve("/users/99/name")
+	assert.False(t, ok)
+
+	_, ok = doc.Resolve("/nope")
+	assert.False(t, ok)
+}
+
+func TestResolveEscapedTokenRoundTrips(t *testing.T) {
+	doc := NewDocument()
+	block := NewBlock("object", "a/b~c")
+	block.AddField("x", "int")
+	block.AddRow(Row{"x": Int(7)})
+	doc.AddBlock(block)
+
+	pointer := "/" + escapePointerToken("a/b~c") + "/x"
+	assert.Equal(t, "/a~1b~0c/x", pointer)
+
+	v, ok := doc.Resolve(pointer)
+	require.True(t, ok)
+	n, _ := v.AsInt()
+	assert.Equal(t, int64(7), n)
+}
+
+func TestApplyPatchAddField(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "add", Path: "/users/0/email", Value: "alice@example.com"}})
+	require.NoError(t, err)
+
+	v, ok := doc.Resolve("/users/0/email")
+	require.True(t, ok)
+	s, _ := v.AsString()
+	assert.Equal(t, "alice@example.com", s)
+
+	block, _ := doc.Get("users")
+	var names []string
+	for _, f := range block.Fields {
+		names = append(names, f.Name)
+	}
+	assert.Contains(t, names, "email")
+}
+
+func TestApplyPatchAddRowAppend(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{
+		Op:    "add",
+		Path:  "/users/-",
+		Value: map[string]interface{}{"id": int64(3), "name": "Carol"},
+	}})
+	require.NoError(t, err)
+
+	block, _ := doc.Get("users")
+	require.Len(t, block.Rows, 3)
+	name, _ := block.Rows[2]["name"].AsString()
+	assert.Equal(t, "Carol", name)
+}
+
+func TestApplyPatchRemoveField(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "remove", Path: "/users/0/name"}})
+	require.NoError(t, err)
+
+	_, ok := doc.Resolve("/users/0/name")
+	assert.False(t, ok)
+}
+
+func TestApplyPatchRemoveRow(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "remove", Path: "/users/0"}})
+	require.NoError(t, err)
+
+	block, _ := doc.Get("users")
+	require.Len(t, block.Rows, 1)
+	name, _ := block.Rows[0]["name"].AsString()
+	assert.Equal(t, "Bob", name)
+}
+
+func TestApplyPatchReplaceField(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "replace", Path: "/users/0/name", Value: "Alicia"}})
+	require.NoError(t, err)
+
+	v, _ := doc.Resolve("/users/0/name")
+	s, _ := v.AsString()
+	assert.Equal(t, "Alicia", s)
+}
+
+func TestApplyPatchMoveField(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "move", From: "/users/0/name", Path: "/users/1/name"}})
+	require.NoError(t, err)
+
+	_, ok := doc.Resolve("/users/0/name")
+	assert.False(t, ok)
+
+	v, ok := doc.Resolve("/users/1/name")
+	require.True(t, ok)
+	s, _ := v.AsString()
+	assert.Equal(t, "Alice", s)
+}
+
+func TestApplyPatchCopyField(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "copy", From: "/users/0/name", Path: "/users/1/name"}})
+	require.NoError(t, err)
+
+	v, _ := doc.Resolve("/users/0/name")
+	s0, _ := v.AsString()
+	v, _ = doc.Resolve("/users/1/name")
+	s1, _ := v.AsString()
+	assert.Equal(t, "Alice", s0)
+	assert.Equal(t, "Alice", s1)
+}
+
+func TestApplyPatchTestPasses(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "test", Path: "/users/0/name", Value: "Alice"}})
+	assert.NoError(t, err)
+}
+
+func TestApplyPatchTestFails(t *testing.T) {
+	doc := patchTestDoc()
+	err := ApplyPatch(doc, []PatchOp{{Op: "test", Path: "/users/0/name", Value: "Bob"}})
+	assert.Error(t, err)
+}
+
+func TestApplyPatchRoundtripPreservesFieldOrderAndTypeHints(t *testing.T) {
+	input := `table.users
+id:int name:string active:bool
+1 Alice true
+2 Bob false
+`
+	doc, err := Parse(input)
+	require.NoError(t, err)
+
+	err = ApplyPatch(doc, []PatchOp{
+		{Op: "replace", Path: "/users/0/name", Value: "Alicia"},
+		{Op: "add", Path: "/users/-", Value: map[string]interface{}{"id": int64(3), "name": "Carol", "active": true}},
+	})
+	require.NoError(t, err)
+
+	output := Dumps(doc)
+	doc2, err := Parse(output)
+	require.NoError(t, err)
+
+	block, _ := doc2.Get("users")
+	assert.Equal(t, []string{"id", "name", "active"}, []string{
+		block.Fields[0].Name, block.Fields[1].Name, block.Fields[2].Name,
+	})
+	assert.Equal(t, "int", block.Fields[0].TypeHint)
+	assert.Equal(t, "string", block.Fields[1].TypeHint)
+	assert.Equal(t, "bool", block.Fields[2].TypeHint)
+
+	require.Len(t, block.Rows, 3)
+	name0, _ := block.Rows[0]["name"].AsString()
+	assert.Equal(t, "Alicia", name0)
+	name2, _ := block.Rows[2]["name"].AsString()
+	assert.Equal(t, "Carol", name2)
+}
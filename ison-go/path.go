@@ -0,0 +1,303 @@
+package ison
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Get navigates doc by a dotted gjson-style path and returns the value
+// found, or a zero Value with Exists()==false if the path doesn't resolve.
+// Supported path syntax:
+//
+//	users.0.name                  row 0 of the users block, field "name"
+//	users.#                       row count of the users block
+//	users.#.name                  the "name" field across every row
+//	users.#(name=="Alice").email  first row matching the predicate, field "email"
+//	users.#(active==true)#        every row matching the predicate
+//
+// A Reference-valued field followed by another path segment is resolved
+// transparently (e.g. "orders.0.user_id.name" follows the :user:1
+// reference into the users block and reads its "name").
+//
+// A path that ends on a row or row-set rather than a field or "#" (e.g.
+// "users.0" or "users.#(active==true)#") resolves to one String Value per
+// row, each holding that row's JSON rendering.
+func Get(doc *Document, path string) Value {
+	values, ok := navigatePath(doc, tokenizePath(path))
+	if !ok || len(values) == 0 {
+		return Value{queryMiss: true}
+	}
+	return values[0]
+}
+
+// GetString is Get plus an AsString unwrap, for the common case of reading
+// a single string field.
+func GetString(doc *Document, path string) (string, bool) {
+	v := Get(doc, path)
+	if !v.Exists() {
+		return "", false
+	}
+	return v.AsString()
+}
+
+// Query navigates doc by path the same way Get does, but returns every
+// value the path resolves to rather than just the first — more than one
+// for a projecting ("#.field") or filter-all ("#(pred)#") path.
+func Query(doc *Document, path string) ([]Value, bool) {
+	return navigatePath(doc, tokenizePath(path))
+}
+
+// ForEach calls fn with every value path resolves to, stopping early if fn
+// returns false.
+func ForEach(doc *Document, path string, fn func(Value) bool) {
+	values, ok := navigatePath(doc, tokenizePath(path))
+	if !ok {
+		return
+	}
+	for _, v := range values {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// tokenizePath splits a path on '.', except inside a "#(...)" predicate,
+// so a predicate's own "==" comparisons can't be mistaken for a path
+// separator.
+func tokenizePath(path string) []string {
+	var segments []string
+	var cur strings.Builder
+	depth := 0
+
+	for _, r := range path {
+		switch {
+		case r == '(':
+			depth++
+			cur.WriteRune(r)
+		case r == ')':
+			depth--
+			cur.WriteRune(r)
+		case r == '.' && depth == 0:
+			segments = append(segments, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		segments = append(segments, cur.String())
+	}
+	return segments
+}
+
+// navState is the evaluator's running position: a block, optionally
+// narrowed down to a set of rows once an index, "#", or predicate segment
+// has been applied.
+type navState struct {
+	block *Block
+	rows  []Row // nil until a row-selecting segment has run
+}
+
+// navigatePath walks segments against doc, implementing Get/Query's shared
+// path grammar. The first segment names a block; every later segment is a
+// row index, "#" (count or select-all), a "#(pred)"/"#(pred)#" filter, or
+// a field name. A path ending on "#" yields a row count, one ending on a
+// field yields that field's values, and one ending on an index or filter
+// yields each selected row's JSON rendering.
+func navigatePath(doc *Document, segments []string) ([]Value, bool) {
+	if len(segments) == 0 {
+		return nil, false
+	}
+	block, ok := doc.Get(segments[0])
+	if !ok {
+		return nil, false
+	}
+	state := navState{block: block}
+
+	var resolver *Resolver
+	resolve := func(ref Reference) (*Block, Row, bool) {
+		if resolver == nil {
+			resolver = NewResolver(doc)
+		}
+		blockName, row, ok := resolver.Resolve(ref)
+		if !ok {
+			return nil, nil, false
+		}
+		target, ok := doc.Get(blockName)
+		return target, row, ok
+	}
+
+	for i := 1; i < len(segments); i++ {
+		seg := segments[i]
+		isLast := i == len(segments)-1
+
+		switch {
+		case seg == "#":
+			if state.rows == nil {
+				if isLast {
+					return []Value{Int(int64(len(state.block.Rows)))}, true
+				}
+				state.rows = append([]Row(nil), state.block.Rows...)
+				continue
+			}
+			return []Value{Int(int64(len(state.rows)))}, true
+
+		case strings.HasPrefix(seg, "#("):
+			matchAll := strings.HasSuffix(seg, ")#")
+			body := seg[len("#("):]
+			if matchAll {
+				body = strings.TrimSuffix(body, ")#")
+			} else {
+				body = strings.TrimSuffix(body, ")")
+			}
+
+			source := state.rows
+			if source == nil {
+				source = state.block.Rows
+			}
+			var matched []Row
+			for _, row := range source {
+				ok, err := evalRowPredicate(body, row)
+				if err == nil && ok {
+					matched = append(matched, row)
+					if !matchAll {
+						break
+					}
+				}
+			}
+			if len(matched) == 0 {
+				return nil, false
+			}
+			state.rows = matched
+
+		default:
+			if state.rows == nil {
+				if idx, err := strconv.Atoi(seg); err == nil {
+					if idx < 0 || idx >= len(state.block.Rows) {
+						return nil, false
+					}
+					state.rows = []Row{state.block.Rows[idx]}
+					continue
+				}
+			}
+
+			rows := state.rows
+			if rows == nil {
+				// Object-block convenience: "config.enabled" needs no
+				// explicit index, since there's only ever one row.
+				if len(state.block.Rows) == 0 {
+					return nil, false
+				}
+				rows = state.block.Rows[:1]
+			}
+
+			values := make([]Value, 0, len(rows))
+			for _, row := range rows {
+				if v, ok := row[seg]; ok {
+					values = append(values, v)
+				}
+			}
+			if len(values) == 0 {
+				return nil, false
+			}
+
+			if isLast {
+				return values, true
+			}
+
+			// A remaining segment only makes sense after a single
+			// Reference field, which we follow transparently.
+			if len(values) != 1 {
+				return nil, false
+			}
+			ref, ok := values[0].AsRef()
+			if !ok {
+				return nil, false
+			}
+			target, row, ok := resolve(ref)
+			if !ok {
+				return nil, false
+			}
+			state = navState{block: target, rows: []Row{row}}
+		}
+	}
+
+	if len(state.rows) == 0 {
+		return nil, false
+	}
+	values := make([]Value, len(state.rows))
+	for i, row := range state.rows {
+		values[i] = rowToJSONValue(row)
+	}
+	return values, true
+}
+
+// rowToJSONValue renders row as a String Value holding its JSON form, the
+// terminal representation for a path that resolves to a whole row rather
+// than a single field.
+func rowToJSONValue(row Row) Value {
+	m := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		m[k] = v.Interface()
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return Null()
+	}
+	return String(string(data))
+}
+
+// pathPredicateOps lists the operators evalRowPredicate recognizes, longest
+// first so "==" isn't mistaken for a stray "=" and "%" (glob match) is
+// checked before the plain ordering operators.
+var pathPredicateOps = []string{"==", "!=", "<=", ">=", "%", "<", ">"}
+
+// evalRowPredicate evaluates a "#(...)" predicate body, e.g. `name=="Alice"`
+// or `version%"1.2.*"`, against row.
+func evalRowPredicate(expr string, row Row) (bool, error) {
+	expr = strings.TrimSpace(expr)
+	for _, op := range pathPredicateOps {
+		idx := indexTopLevel(expr, op)
+		if idx < 0 {
+			continue
+		}
+		left, err := evalOperand(expr[:idx], row)
+		if err != nil {
+			return false, err
+		}
+		right, err := evalOperand(expr[idx+len(op):], row)
+		if err != nil {
+			return false, err
+		}
+		if op == "%" {
+			pattern, _ := right.AsString()
+			text, _ := left.AsString()
+			return globMatch(pattern, text), nil
+		}
+		return compareValues(left, right, op)
+	}
+	return false, fmt.Errorf("ison: unrecognized predicate expression %q", expr)
+}
+
+// globMatch reports whether text matches pattern, where "*" matches any
+// run of characters.
+func globMatch(pattern, text string) bool {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		if r == '*' {
+			sb.WriteString(".*")
+		} else {
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(text)
+}
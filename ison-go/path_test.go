@@ -0,0 +1,148 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pathTestDoc() *Document {
+	doc := NewDocument()
+
+	users := NewBlock("table", "users")
+	users.AddField("id", "int")
+	users.AddField("name", "string")
+	users.AddField("active", "bool")
+	users.AddRow(Row{"id": Int(1), "name": String("Alice"), "active": Bool(true)})
+	users.AddRow(Row{"id": Int(2), "name": String("Bob"), "active": Bool(false)})
+	doc.AddBlock(users)
+
+	orders := NewBlock("table", "orders")
+	orders.AddField("id", "int")
+	orders.AddField("user_id", "ref")
+	orders.AddRow(Row{"id": Int(1), "user_id": Ref(Reference{ID: "1", Namespace: "user"})})
+	orders.AddRow(Row{"id": Int(2), "user_id": Ref(Reference{ID: "99", Namespace: "user"})})
+	doc.AddBlock(orders)
+
+	config := NewBlock("object", "config")
+	config.AddField("enabled", "bool")
+	config.AddRow(Row{"enabled": Bool(true)})
+	doc.AddBlock(config)
+
+	return doc
+}
+
+func TestGetIndexesRowField(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "users.0.name")
+	require.True(t, v.Exists())
+	name, _ := v.AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+func TestGetRowCount(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "users.#")
+	n, ok := v.AsInt()
+	require.True(t, ok)
+	assert.Equal(t, int64(2), n)
+}
+
+func TestQueryProjectsFieldAcrossRows(t *testing.T) {
+	doc := pathTestDoc()
+	values, ok := Query(doc, "users.#.name")
+	require.True(t, ok)
+	require.Len(t, values, 2)
+	n0, _ := values[0].AsString()
+	n1, _ := values[1].AsString()
+	assert.Equal(t, []string{"Alice", "Bob"}, []string{n0, n1})
+}
+
+func TestGetFilterReturnsFirstMatch(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, `users.#(name=="Alice").id`)
+	id, ok := v.AsInt()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestGetFilterMatchesLiteralOperatorCharsInsideQuotes(t *testing.T) {
+	doc := pathTestDoc()
+	users, _ := doc.Get("users")
+	users.AddRow(Row{"id": Int(3), "name": String("x==y"), "active": Bool(true)})
+
+	v := Get(doc, `users.#(name=="x==y").id`)
+	id, ok := v.AsInt()
+	require.True(t, ok)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestQueryFilterAllReturnsEveryMatch(t *testing.T) {
+	doc := pathTestDoc()
+	values, ok := Query(doc, "users.#(active==true)#")
+	require.True(t, ok)
+	require.Len(t, values, 1)
+	row, _ := values[0].AsString()
+	assert.Contains(t, row, "Alice")
+}
+
+func TestGetFollowsReferenceTransparently(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "orders.0.user_id.name")
+	name, ok := v.AsString()
+	require.True(t, ok)
+	assert.Equal(t, "Alice", name)
+}
+
+func TestGetDanglingReferenceMisses(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "orders.1.user_id.name")
+	assert.False(t, v.Exists())
+}
+
+func TestGetObjectBlockImplicitRow(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "config.enabled")
+	b, ok := v.AsBool()
+	require.True(t, ok)
+	assert.True(t, b)
+}
+
+func TestGetMissingPathReportsNotExists(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, "users.99.name")
+	assert.False(t, v.Exists())
+
+	v = Get(doc, "nope.0.name")
+	assert.False(t, v.Exists())
+}
+
+func TestGetStringConvenienceAccessor(t *testing.T) {
+	doc := pathTestDoc()
+	s, ok := GetString(doc, "users.0.name")
+	require.True(t, ok)
+	assert.Equal(t, "Alice", s)
+
+	_, ok = GetString(doc, "users.0.missing")
+	assert.False(t, ok)
+}
+
+func TestForEachStopsEarly(t *testing.T) {
+	doc := pathTestDoc()
+	var seen []string
+	ForEach(doc, "users.#.name", func(v Value) bool {
+		s, _ := v.AsString()
+		seen = append(seen, s)
+		return false
+	})
+	assert.Equal(t, []string{"Alice"}, seen)
+}
+
+func TestGetGlobPredicate(t *testing.T) {
+	doc := pathTestDoc()
+	v := Get(doc, `users.#(name%"A*").id`)
+	id, ok := v.AsInt()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), id)
+}
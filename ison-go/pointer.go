@@ -0,0 +1,102 @@
+package ison
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitPointer decodes an RFC 6901 JSON Pointer into its unescaped
+// reference tokens ("~1" -> "/", "~0" -> "~", in that order).
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("ison: JSON pointer must start with \"/\", got %q", pointer)
+	}
+	raw := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(raw))
+	for i, t := range raw {
+		t = strings.ReplaceAll(t, "~1", "/")
+		t = strings.ReplaceAll(t, "~0", "~")
+		tokens[i] = t
+	}
+	return tokens, nil
+}
+
+// escapePointerToken encodes a single reference token per RFC 6901.
+func escapePointerToken(t string) string {
+	t = strings.ReplaceAll(t, "~", "~0")
+	t = strings.ReplaceAll(t, "/", "~1")
+	return t
+}
+
+// Resolve navigates doc by an RFC 6901 JSON Pointer, e.g. "/users/0/name"
+// (row 0 of the users table, field "name") or "/config/debug" (object
+// block "config"'s "debug" field). A pointer that ends on a whole block or
+// a whole row resolves to a String Value holding its JSON rendering,
+// mirroring how Get handles whole-row path results.
+func (d *Document) Resolve(pointer string) (Value, bool) {
+	tokens, err := splitPointer(pointer)
+	if err != nil || len(tokens) == 0 {
+		return Value{queryMiss: true}, false
+	}
+
+	block, ok := d.Get(tokens[0])
+	if !ok {
+		return Value{queryMiss: true}, false
+	}
+	rest := tokens[1:]
+	if len(rest) == 0 {
+		return blockToJSONValue(block), true
+	}
+
+	var row Row
+	var fieldTokens []string
+	if block.Kind == "object" {
+		if len(block.Rows) == 0 {
+			return Value{queryMiss: true}, false
+		}
+		row = block.Rows[0]
+		fieldTokens = rest
+	} else {
+		idx, err := strconv.Atoi(rest[0])
+		if err != nil || idx < 0 || idx >= len(block.Rows) {
+			return Value{queryMiss: true}, false
+		}
+		row = block.Rows[idx]
+		fieldTokens = rest[1:]
+	}
+
+	if len(fieldTokens) == 0 {
+		return rowToJSONValue(row), true
+	}
+	if len(fieldTokens) > 1 {
+		return Value{queryMiss: true}, false
+	}
+	v, ok := row[fieldTokens[0]]
+	if !ok {
+		return Value{queryMiss: true}, false
+	}
+	return v, true
+}
+
+// blockToJSONValue renders every row in block as a JSON array string, the
+// whole-block counterpart to rowToJSONValue.
+func blockToJSONValue(block *Block) Value {
+	rows := make([]map[string]interface{}, len(block.Rows))
+	for i, row := range block.Rows {
+		m := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			m[k] = v.Interface()
+		}
+		rows[i] = m
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return Null()
+	}
+	return String(string(data))
+}
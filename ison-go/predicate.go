@@ -0,0 +1,64 @@
+package ison
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// predicateClause is one compiled Query.Where clause: a small boolean
+// expression (same grammar as evalPredicate) with every "?" placeholder
+// already substituted by a literal rendering of its argument.
+type predicateClause struct {
+	expr string
+}
+
+func (c predicateClause) matches(row Row) (bool, error) {
+	return evalPredicate(c.expr, row)
+}
+
+// compilePredicate substitutes each "?" in expr, in order, with a literal
+// rendering of the matching entry in args, producing a clause evalPredicate
+// can run against any row. Query.Where ANDs the clauses it compiles this
+// way together across calls.
+func compilePredicate(expr string, args []interface{}) (predicateClause, error) {
+	argIdx := 0
+	var b strings.Builder
+	for i := 0; i < len(expr); i++ {
+		if expr[i] != '?' {
+			b.WriteByte(expr[i])
+			continue
+		}
+		if argIdx >= len(args) {
+			return predicateClause{}, fmt.Errorf("ison: not enough arguments for %q", expr)
+		}
+		b.WriteString(literalFor(args[argIdx]))
+		argIdx++
+	}
+	if argIdx < len(args) {
+		return predicateClause{}, fmt.Errorf("ison: too many arguments for %q", expr)
+	}
+	return predicateClause{expr: b.String()}, nil
+}
+
+// literalFor renders a Where argument the way evalOperand expects to find
+// it in a predicate expression: a quoted string, or the plain textual form
+// of anything else.
+func literalFor(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return strconv.Quote(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
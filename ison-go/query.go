@@ -0,0 +1,304 @@
+package ison
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AggOp identifies a GroupBy/Aggregate reducer.
+type AggOp string
+
+const (
+	AggSum   AggOp = "sum"
+	AggCount AggOp = "count"
+	AggAvg   AggOp = "avg"
+)
+
+// Aggregate is one column reduction over a group of rows: Field is summed
+// or averaged (ignored for AggCount), and the result is written to the
+// output row's As field — the same role a table's SummaryRow plays for a
+// whole, non-grouped block.
+type Aggregate struct {
+	Op    AggOp
+	Field string
+	As    string
+}
+
+// Sum reduces field by summation, written to the output row under as.
+func Sum(field, as string) Aggregate { return Aggregate{Op: AggSum, Field: field, As: as} }
+
+// Count reduces a group down to its row count, written to the output row
+// under as.
+func Count(as string) Aggregate { return Aggregate{Op: AggCount, As: as} }
+
+// Avg reduces field by averaging, written to the output row under as.
+func Avg(field, as string) Aggregate { return Aggregate{Op: AggAvg, Field: field, As: as} }
+
+// TableQuery is a fluent, relational query over one of a Document's table
+// blocks, treating the block as a relation and Reference fields as foreign
+// keys. Build one up with Where/Select/Join/LeftJoin/GroupBy/OrderBy/Limit
+// and run it with Find or Aggregate.
+type TableQuery struct {
+	doc     *Document
+	table   string
+	wheres  []predicateClause
+	fields  []string
+	joins   []joinStep
+	groupBy string
+	aggs    []Aggregate
+	orderBy string
+	limit   int
+	err     error
+}
+
+// Table starts a TableQuery over the table block named name.
+func (d *Document) Table(name string) *TableQuery {
+	return &TableQuery{doc: d, table: name, limit: -1}
+}
+
+// Where ANDs a predicate clause onto the query, e.g.
+// q.Where("amount > ?", 100). Each "?" in expr is substituted, in order, by
+// the matching arg. Multiple Where calls accumulate, same as chained
+// "AND"s.
+func (q *TableQuery) Where(expr string, args ...interface{}) *TableQuery {
+	clause, err := compilePredicate(expr, args)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	q.wheres = append(q.wheres, clause)
+	return q
+}
+
+// Select narrows result rows down to fields, in order. Unset (the zero
+// value) means every field the pipeline produced.
+func (q *TableQuery) Select(fields ...string) *TableQuery {
+	q.fields = fields
+	return q
+}
+
+// Join merges, into each result row, the row in table whose "id" matches
+// the reference held in on. If on is "", the reference field is inferred
+// from its namespace matching table (singular or plural), the same
+// convention Resolver.Resolve uses. Rows whose reference doesn't resolve
+// are dropped.
+func (q *TableQuery) Join(table, on string) *TableQuery {
+	q.joins = append(q.joins, joinStep{table: table, on: on})
+	return q
+}
+
+// LeftJoin is Join, but keeps rows whose reference doesn't resolve, with
+// the joined fields simply absent instead of dropping the row.
+func (q *TableQuery) LeftJoin(table, on string) *TableQuery {
+	q.joins = append(q.joins, joinStep{table: table, on: on, left: true})
+	return q
+}
+
+// GroupBy groups rows by field, reducing each group to a single row via
+// aggs. Combine with Find as the terminal call; for a single summary row
+// over the whole query (no grouping), use Aggregate instead.
+func (q *TableQuery) GroupBy(field string, aggs ...Aggregate) *TableQuery {
+	q.groupBy = field
+	q.aggs = aggs
+	return q
+}
+
+// OrderBy sorts results by expr, a field name optionally followed by
+// "desc" (default ascending), the same grammar as View.Sort.
+func (q *TableQuery) OrderBy(expr string) *TableQuery {
+	q.orderBy = expr
+	return q
+}
+
+// Limit caps the number of rows Find returns.
+func (q *TableQuery) Limit(n int) *TableQuery {
+	q.limit = n
+	return q
+}
+
+// filtered runs Where and Join, the portion of the pipeline shared by Find
+// and Aggregate, returning the fields in play and the surviving rows.
+func (q *TableQuery) filtered() ([]string, []Row, error) {
+	if q.err != nil {
+		return nil, nil, q.err
+	}
+	block, ok := q.doc.Get(q.table)
+	if !ok {
+		return nil, nil, fmt.Errorf("ison: table %q not found", q.table)
+	}
+
+	fields := block.GetFieldNames()
+	rows := append([]Row(nil), block.Rows...)
+
+	for _, clause := range q.wheres {
+		filtered := rows[:0]
+		for _, row := range rows {
+			ok, err := clause.matches(row)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+
+	for _, j := range q.joins {
+		var err error
+		fields, rows, err = j.apply(q.doc, fields, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return fields, rows, nil
+}
+
+// rows runs the full Where/Join/GroupBy/Select/OrderBy/Limit pipeline.
+func (q *TableQuery) rows() ([]string, []Row, error) {
+	fields, rows, err := q.filtered()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if q.groupBy != "" {
+		fields, rows = groupRows(rows, q.groupBy, q.aggs)
+	}
+
+	if len(q.fields) > 0 {
+		fields, rows = applyProject(rows, q.fields)
+	}
+
+	if q.orderBy != "" {
+		rows = applySort(rows, q.orderBy)
+	}
+
+	if q.limit >= 0 && q.limit < len(rows) {
+		rows = rows[:q.limit]
+	}
+
+	return fields, rows, nil
+}
+
+// Find executes the query and decodes its result rows into dst, which must
+// be a pointer to either []Row or a slice of structs with `ison:"..."`
+// tags, the same tagging Unmarshal/ToStruct use.
+func (q *TableQuery) Find(dst interface{}) error {
+	_, rows, err := q.rows()
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ison: Find requires a pointer to a slice, got %T", dst)
+	}
+	elem := rv.Elem()
+
+	if elem.Type().Elem() == reflect.TypeOf(Row{}) {
+		slice := reflect.MakeSlice(elem.Type(), len(rows), len(rows))
+		for i, row := range rows {
+			slice.Index(i).Set(reflect.ValueOf(row))
+		}
+		elem.Set(slice)
+		return nil
+	}
+
+	elemType := derefType(elem.Type().Elem())
+	codec := getCodec(elemType)
+	slice := reflect.MakeSlice(elem.Type(), len(rows), len(rows))
+	for i, row := range rows {
+		target := slice.Index(i)
+		if target.Kind() == reflect.Ptr {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+		if err := decodeRow(codec, row, target); err != nil {
+			return err
+		}
+	}
+	elem.Set(slice)
+	return nil
+}
+
+// Aggregate reduces the query's rows (after Where/Join, ignoring Select/
+// GroupBy/OrderBy/Limit) to a single row via aggs — the query-builder
+// counterpart to a table's SummaryRow, computed on demand instead of
+// parsed from a "---" separator.
+func (q *TableQuery) Aggregate(aggs ...Aggregate) (Row, error) {
+	_, rows, err := q.filtered()
+	if err != nil {
+		return nil, err
+	}
+	return reduceRows(rows, aggs), nil
+}
+
+// groupRows partitions rows by groupField (preserving first-seen group
+// order) and reduces each group to one row via aggs.
+func groupRows(rows []Row, groupField string, aggs []Aggregate) ([]string, []Row) {
+	var order []string
+	groups := make(map[string][]Row)
+	for _, row := range rows {
+		key := valueKey(row[groupField])
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	fields := make([]string, 0, len(aggs)+1)
+	fields = append(fields, groupField)
+	for _, a := range aggs {
+		fields = append(fields, a.As)
+	}
+
+	out := make([]Row, 0, len(order))
+	firstByKey := make(map[string]Row, len(order))
+	for _, row := range rows {
+		key := valueKey(row[groupField])
+		if _, ok := firstByKey[key]; !ok {
+			firstByKey[key] = row
+		}
+	}
+	for _, key := range order {
+		group := groups[key]
+		row := Row{groupField: firstByKey[key][groupField]}
+		for k, v := range reduceRows(group, aggs) {
+			row[k] = v
+		}
+		out = append(out, row)
+	}
+	return fields, out
+}
+
+// reduceRows collapses rows to a single Row holding each Aggregate's
+// result under its As name.
+func reduceRows(rows []Row, aggs []Aggregate) Row {
+	out := make(Row, len(aggs))
+	for _, a := range aggs {
+		switch a.Op {
+		case AggCount:
+			out[a.As] = Int(int64(len(rows)))
+		case AggSum:
+			out[a.As] = Float(sumField(rows, a.Field))
+		case AggAvg:
+			if len(rows) == 0 {
+				out[a.As] = Float(0)
+				continue
+			}
+			out[a.As] = Float(sumField(rows, a.Field) / float64(len(rows)))
+		}
+	}
+	return out
+}
+
+func sumField(rows []Row, field string) float64 {
+	var sum float64
+	for _, row := range rows {
+		if f, ok := row[field].AsFloat(); ok {
+			sum += f
+		}
+	}
+	return sum
+}
@@ -0,0 +1,208 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryTestDoc() *Document {
+	doc := NewDocument()
+
+	users := NewBlock("table", "users")
+	users.AddField("id", "int")
+	users.AddField("name", "string")
+	users.AddRow(Row{"id": Int(1), "name": String("Alice")})
+	users.AddRow(Row{"id": Int(2), "name": String("Bob")})
+	doc.AddBlock(users)
+
+	orders := NewBlock("table", "orders")
+	orders.AddField("id", "int")
+	orders.AddField("user_id", "ref")
+	orders.AddField("amount", "float")
+	orders.AddRow(Row{"id": Int(1), "user_id": Ref(Reference{ID: "1", Namespace: "user"}), "amount": Float(50)})
+	orders.AddRow(Row{"id": Int(2), "user_id": Ref(Reference{ID: "1", Namespace: "user"}), "amount": Float(150)})
+	orders.AddRow(Row{"id": Int(3), "user_id": Ref(Reference{ID: "2", Namespace: "user"}), "amount": Float(300)})
+	orders.AddRow(Row{"id": Int(4), "user_id": Ref(Reference{ID: "99", Namespace: "user"}), "amount": Float(10)})
+	doc.AddBlock(orders)
+
+	return doc
+}
+
+func TestQueryWhereFiltersRows(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("amount > ?", 100).Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		amount, _ := row["amount"].AsFloat()
+		assert.Greater(t, amount, 100.0)
+	}
+}
+
+func TestQueryWhereMultipleClausesAreAnded(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("amount > ?", 10).Where("amount < ?", 200).Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+}
+
+func TestQuerySelectNarrowsFields(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Select("id").Find(&rows)
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+	for _, row := range rows {
+		assert.Len(t, row, 1)
+		_, ok := row["id"]
+		assert.True(t, ok)
+	}
+}
+
+func TestQueryOrderByAndLimit(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").OrderBy("amount desc").Limit(2).Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	a0, _ := rows[0]["amount"].AsFloat()
+	a1, _ := rows[1]["amount"].AsFloat()
+	assert.Equal(t, 300.0, a0)
+	assert.Equal(t, 150.0, a1)
+}
+
+func TestQueryJoinExplicitField(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("amount > ?", 100).Join("users", "user_id").Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		_, ok := row["users.name"]
+		assert.True(t, ok)
+	}
+}
+
+func TestQueryLeftJoinKeepsDanglingRows(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("id == ?", 4).LeftJoin("users", "user_id").Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	_, ok := rows[0]["users.name"]
+	assert.False(t, ok)
+}
+
+func TestQueryJoinDropsDanglingRows(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("id == ?", 4).Join("users", "user_id").Find(&rows)
+	require.NoError(t, err)
+	assert.Len(t, rows, 0)
+}
+
+func TestQueryJoinInfersReferenceField(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("id == ?", 1).Join("users", "").Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	name, ok := rows[0]["users.name"].AsString()
+	require.True(t, ok)
+	assert.Equal(t, "Alice", name)
+}
+
+func TestQueryGroupByAggregates(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("id != ?", 4).
+		GroupBy("user_id", Sum("amount", "total"), Count("n")).
+		Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	byUser := map[string]Row{}
+	for _, row := range rows {
+		byUser[refKey(row["user_id"])] = row
+	}
+
+	total1, _ := byUser["1"]["total"].AsFloat()
+	n1, _ := byUser["1"]["n"].AsInt()
+	assert.Equal(t, 200.0, total1)
+	assert.Equal(t, int64(2), n1)
+
+	total2, _ := byUser["2"]["total"].AsFloat()
+	n2, _ := byUser["2"]["n"].AsInt()
+	assert.Equal(t, 300.0, total2)
+	assert.Equal(t, int64(1), n2)
+}
+
+func TestQueryAggregateReducesToSingleRow(t *testing.T) {
+	doc := queryTestDoc()
+	row, err := doc.Table("orders").Where("amount > ?", 10).Aggregate(Sum("amount", "total"), Avg("amount", "avg"), Count("n"))
+	require.NoError(t, err)
+
+	total, _ := row["total"].AsFloat()
+	n, _ := row["n"].AsInt()
+	avg, _ := row["avg"].AsFloat()
+	assert.Equal(t, 500.0, total)
+	assert.Equal(t, int64(3), n)
+	assert.InDelta(t, 500.0/3.0, avg, 0.001)
+}
+
+type queryOrder struct {
+	ID     int64   `ison:"id"`
+	Amount float64 `ison:"amount"`
+}
+
+func TestQueryFindDecodesIntoStructSlice(t *testing.T) {
+	doc := queryTestDoc()
+	var orders []queryOrder
+	err := doc.Table("orders").Where("amount > ?", 100).OrderBy("id").Find(&orders)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, int64(2), orders[0].ID)
+	assert.Equal(t, 150.0, orders[0].Amount)
+	assert.Equal(t, int64(3), orders[1].ID)
+}
+
+func TestQueryWhereArgContainingOperatorCharsComparesLiterally(t *testing.T) {
+	doc := queryTestDoc()
+	users, ok := doc.Get("users")
+	require.True(t, ok)
+	users.AddRow(Row{"id": Int(3), "name": String("a==b")})
+
+	var rows []Row
+	err := doc.Table("users").Where("name == ?", "a==b").Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(3), rows[0]["id"].IntVal)
+}
+
+func TestQueryWhereStringComparison(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("users").Where("name == ?", "Alice").Find(&rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	name, _ := rows[0]["name"].AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+func TestQueryUnknownTableErrors(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("nope").Find(&rows)
+	assert.Error(t, err)
+}
+
+func TestQueryWhereArgumentMismatchErrors(t *testing.T) {
+	doc := queryTestDoc()
+	var rows []Row
+	err := doc.Table("orders").Where("amount > ?").Find(&rows)
+	assert.Error(t, err)
+}
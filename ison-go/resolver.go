@@ -0,0 +1,231 @@
+package ison
+
+import "fmt"
+
+// ResolverOptions configures how a Resolver identifies rows within blocks.
+type ResolverOptions struct {
+	// IDField maps a block name to the field used as its row identifier.
+	// Blocks not present in the map use "id".
+	IDField map[string]string
+}
+
+// DefaultResolverOptions returns default resolver options.
+func DefaultResolverOptions() ResolverOptions {
+	return ResolverOptions{IDField: map[string]string{}}
+}
+
+// refEdge records a single Reference value found while indexing a Document,
+// so Resolver can report it (Validate) or enumerate it (Referents).
+type refEdge struct {
+	Block    string
+	RowIndex int
+	Row      Row
+	Field    string
+	Ref      Reference
+}
+
+// RefError describes a Reference that does not resolve to any row.
+type RefError struct {
+	Block    string
+	RowIndex int
+	Field    string
+	Ref      Reference
+}
+
+// Error implements the error interface.
+func (e RefError) Error() string {
+	return fmt.Sprintf("ison: dangling reference %s in %s row %d field %q", e.Ref.ToISON(), e.Block, e.RowIndex, e.Field)
+}
+
+// Resolver indexes a Document's blocks by row ID so References parsed from
+// ISON text can be followed back to the concrete rows they point at,
+// turning the Document into a lightweight in-memory graph.
+type Resolver struct {
+	doc     *Document
+	idField map[string]string
+
+	// byBlock[blockName][id] is the row whose ID field equals id.
+	byBlock map[string]map[string]Row
+
+	// edges holds every Reference value found while indexing, used by
+	// Validate and, for relationship refs, Referents.
+	edges []refEdge
+
+	// byRelation[relationship][id] lists every edge carrying that exact
+	// relationship reference, indexed separately from byBlock so
+	// Referents can enumerate rows on either side of the relation.
+	byRelation map[string]map[string][]refEdge
+}
+
+// NewResolver builds a Resolver over doc using the default options (rows
+// are identified by an "id" field).
+func NewResolver(doc *Document) *Resolver {
+	return NewResolverWithOptions(doc, DefaultResolverOptions())
+}
+
+// NewResolverWithOptions builds a Resolver over doc, indexing each block's
+// rows by opts.IDField[blockName] (or "id" if unset) and recording every
+// Reference value found in any row's fields.
+func NewResolverWithOptions(doc *Document, opts ResolverOptions) *Resolver {
+	r := &Resolver{
+		doc:        doc,
+		idField:    opts.IDField,
+		byBlock:    make(map[string]map[string]Row),
+		byRelation: make(map[string]map[string][]refEdge),
+	}
+	if r.idField == nil {
+		r.idField = map[string]string{}
+	}
+
+	for _, name := range doc.Order {
+		block := doc.Blocks[name]
+		idField := r.idFieldFor(name)
+		rows := make(map[string]Row, len(block.Rows))
+
+		for i, row := range block.Rows {
+			if v, ok := row[idField]; ok {
+				rows[valueKey(v)] = row
+			}
+			for field, v := range row {
+				if v.Type != TypeReference {
+					continue
+				}
+				edge := refEdge{Block: name, RowIndex: i, Row: row, Field: field, Ref: v.RefVal}
+				r.edges = append(r.edges, edge)
+				if v.RefVal.IsRelationship() {
+					byID := r.byRelation[v.RefVal.Relationship]
+					if byID == nil {
+						byID = make(map[string][]refEdge)
+						r.byRelation[v.RefVal.Relationship] = byID
+					}
+					byID[v.RefVal.ID] = append(byID[v.RefVal.ID], edge)
+				}
+			}
+		}
+		r.byBlock[name] = rows
+	}
+
+	return r
+}
+
+func (r *Resolver) idFieldFor(blockName string) string {
+	if field, ok := r.idField[blockName]; ok {
+		return field
+	}
+	return "id"
+}
+
+func valueKey(v Value) string {
+	if s, ok := v.AsString(); ok {
+		return s
+	}
+	if i, ok := v.AsInt(); ok {
+		return fmt.Sprintf("%d", i)
+	}
+	return v.ToISON()
+}
+
+// lookupAnyBlock searches every indexed block for a row with the given ID,
+// used for relationship and bare references that do not name a block.
+func (r *Resolver) lookupAnyBlock(id string) (string, Row, bool) {
+	for _, name := range r.doc.Order {
+		if row, ok := r.byBlock[name][id]; ok {
+			return name, row, true
+		}
+	}
+	return "", nil, false
+}
+
+// Resolve follows ref back to the concrete row it points at. Namespaced
+// refs (e.g. :user:42) are looked up in the block named after the
+// namespace, or that name pluralized with "s". Relationship refs (e.g.
+// :OWNS:5) and bare refs (e.g. :1) carry no block name, so every indexed
+// block is searched for a matching ID.
+func (r *Resolver) Resolve(ref Reference) (blockName string, row Row, ok bool) {
+	if ref.Namespace != "" {
+		if row, ok := r.byBlock[ref.Namespace][ref.ID]; ok {
+			return ref.Namespace, row, true
+		}
+		plural := ref.Namespace + "s"
+		if row, ok := r.byBlock[plural][ref.ID]; ok {
+			return plural, row, true
+		}
+		return "", nil, false
+	}
+	return r.lookupAnyBlock(ref.ID)
+}
+
+// Referents returns every row on either side of ref's relation: the target
+// row whose ID matches ref.ID (the first such row in block order), and
+// every row that holds a reference to
+// that exact relationship and ID (the "owners" of the relation). For
+// non-relationship refs it simply returns the single resolved row, if any.
+func (r *Resolver) Referents(ref Reference) []Row {
+	if !ref.IsRelationship() {
+		if _, row, ok := r.Resolve(ref); ok {
+			return []Row{row}
+		}
+		return nil
+	}
+
+	var rows []Row
+	if _, row, ok := r.lookupAnyBlock(ref.ID); ok {
+		rows = append(rows, row)
+	}
+	for _, edge := range r.byRelation[ref.Relationship][ref.ID] {
+		rows = append(rows, edge.Row)
+	}
+	return rows
+}
+
+// Validate checks every Reference found while indexing the Document and
+// returns one RefError per dangling reference, suitable for CI-style
+// integrity checks over ISON data.
+func (r *Resolver) Validate() []RefError {
+	var errs []RefError
+	for _, edge := range r.edges {
+		if _, _, ok := r.Resolve(edge.Ref); !ok {
+			errs = append(errs, RefError{Block: edge.Block, RowIndex: edge.RowIndex, Field: edge.Field, Ref: edge.Ref})
+		}
+	}
+	return errs
+}
+
+// Walk performs a depth-first traversal of the reference graph starting at
+// root, calling visit with each row reached. It follows every Reference
+// field found in a row's values, resolving and visiting the rows they point
+// to in turn. Rows already visited are skipped, so cyclic references
+// terminate the walk instead of looping forever. Walk stops as soon as
+// visit returns false.
+func (r *Resolver) Walk(root Reference, visit func(Row) bool) {
+	visited := make(map[string]bool)
+
+	var walk func(ref Reference) bool
+	walk = func(ref Reference) bool {
+		blockName, row, ok := r.Resolve(ref)
+		if !ok {
+			return true
+		}
+
+		key := blockName + "\x00" + valueKey(row[r.idFieldFor(blockName)])
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+
+		if !visit(row) {
+			return false
+		}
+
+		for _, v := range row {
+			if v.Type == TypeReference {
+				if !walk(v.RefVal) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	walk(root)
+}
@@ -0,0 +1,91 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resolverTestDoc(t *testing.T) *Document {
+	t.Helper()
+	input := `
+table.users
+id name
+1 Alice
+2 Bob
+
+table.products
+id name owner
+1 Widget :user:1
+2 Gadget :user:99
+
+table.orders
+id user_id product_id
+1 :OWNS:1 :1
+2 :OWNS:1 :2
+`
+	doc, err := Parse(input)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestResolverResolvesNamespacedReference(t *testing.T) {
+	doc := resolverTestDoc(t)
+	r := NewResolver(doc)
+
+	block, row, ok := r.Resolve(Reference{ID: "1", Namespace: "user"})
+	require.True(t, ok)
+	assert.Equal(t, "users", block)
+	name, _ := row["name"].AsString()
+	assert.Equal(t, "Alice", name)
+}
+
+func TestResolverResolveReportsDanglingReference(t *testing.T) {
+	doc := resolverTestDoc(t)
+	r := NewResolver(doc)
+
+	_, _, ok := r.Resolve(Reference{ID: "99", Namespace: "user"})
+	assert.False(t, ok)
+}
+
+func TestResolverValidateFindsDanglingReferences(t *testing.T) {
+	doc := resolverTestDoc(t)
+	r := NewResolver(doc)
+
+	errs := r.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "products", errs[0].Block)
+	assert.Equal(t, "owner", errs[0].Field)
+	assert.Equal(t, "99", errs[0].Ref.ID)
+	assert.Contains(t, errs[0].Error(), ":user:99")
+}
+
+func TestResolverReferentsFansOutRelationship(t *testing.T) {
+	doc := resolverTestDoc(t)
+	r := NewResolver(doc)
+
+	rows := r.Referents(Reference{ID: "1", Relationship: "OWNS"})
+	require.Len(t, rows, 3) // the owned user row + both orders referencing it
+
+	name, ok := rows[0]["name"].AsString()
+	require.True(t, ok)
+	assert.Equal(t, "Alice", name)
+}
+
+func TestResolverWalkVisitsConnectedRowsWithoutLooping(t *testing.T) {
+	doc := resolverTestDoc(t)
+	r := NewResolverWithOptions(doc, ResolverOptions{IDField: map[string]string{
+		"users": "id", "products": "id", "orders": "id",
+	}})
+
+	var visited []Row
+	r.Walk(Reference{ID: "1", Namespace: "product"}, func(row Row) bool {
+		visited = append(visited, row)
+		return true
+	})
+
+	require.Len(t, visited, 2) // the product itself, then the user that owns it
+	name, _ := visited[0]["name"].AsString()
+	assert.Equal(t, "Widget", name)
+}
@@ -0,0 +1,250 @@
+package ison
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldSchema declares one field's expected shape within a BlockSchema.
+type FieldSchema struct {
+	Name     string
+	Type     string // expected TypeHint ("int", "string", "float", "bool", "semver", "ref"), or "" for any
+	Nullable bool
+	Ref      string // for Type == "ref": the block its values must resolve into ("" to accept any block)
+}
+
+// BlockSchema declares one block's expected shape: its kind, fields, and
+// which fields form its primary key.
+type BlockSchema struct {
+	Name   string
+	Kind   string // "table" or "object"; "" accepts either
+	Fields []FieldSchema
+	Keys   []string // field names whose combined value must be unique per row
+}
+
+// Schema declares the blocks a Document is expected to contain, for
+// Document.Validate to check data against. Build one programmatically with
+// NewSchema/AddBlock, or parse one from a schema.ison document with
+// ParseSchema.
+type Schema struct {
+	Blocks []BlockSchema
+}
+
+// NewSchema creates an empty Schema, ready for AddBlock calls.
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// AddBlock appends a block's schema, returning it for chaining Field/
+// RefField/PrimaryKey calls onto.
+func (s *Schema) AddBlock(name, kind string) *BlockSchema {
+	s.Blocks = append(s.Blocks, BlockSchema{Name: name, Kind: kind})
+	return &s.Blocks[len(s.Blocks)-1]
+}
+
+// Field declares one of the block's fields and its expected type hint.
+func (bs *BlockSchema) Field(name, typeHint string, nullable bool) *BlockSchema {
+	bs.Fields = append(bs.Fields, FieldSchema{Name: name, Type: typeHint, Nullable: nullable})
+	return bs
+}
+
+// RefField declares a field expected to hold a Reference resolving into
+// target ("" to accept a reference into any block).
+func (bs *BlockSchema) RefField(name, target string, nullable bool) *BlockSchema {
+	bs.Fields = append(bs.Fields, FieldSchema{Name: name, Type: "ref", Ref: target, Nullable: nullable})
+	return bs
+}
+
+// PrimaryKey declares the fields (commonly just "id") whose combined value
+// must be unique across the block's rows.
+func (bs *BlockSchema) PrimaryKey(fields ...string) *BlockSchema {
+	bs.Keys = fields
+	return bs
+}
+
+// ParseSchema parses a schema.ison document into a Schema, using the
+// reserved "schema.<name>" block kind: each row of a schema.<name> block
+// describes one field of block <name> via columns "field", "type",
+// "nullable", "key", and, for ref fields, "ref".
+func ParseSchema(text string) (*Schema, error) {
+	doc, err := Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return SchemaFromDocument(doc)
+}
+
+// SchemaFromDocument builds a Schema from every "schema.<name>" block in
+// doc, the Document-based counterpart to ParseSchema.
+func SchemaFromDocument(doc *Document) (*Schema, error) {
+	schema := NewSchema()
+	for _, name := range doc.Order {
+		block := doc.Blocks[name]
+		if block.Kind != "schema" {
+			continue
+		}
+		bs := schema.AddBlock(name, "")
+		for _, row := range block.Rows {
+			fieldName, _ := row["field"].AsString()
+			if fieldName == "" {
+				return nil, fmt.Errorf("ison: schema.%s row missing \"field\"", name)
+			}
+			typeHint, _ := row["type"].AsString()
+			nullable, _ := row["nullable"].AsBool()
+			isKey, _ := row["key"].AsBool()
+			ref, _ := row["ref"].AsString()
+
+			if typeHint == "ref" {
+				bs.RefField(fieldName, ref, nullable)
+			} else {
+				bs.Field(fieldName, typeHint, nullable)
+			}
+			if isKey {
+				bs.Keys = append(bs.Keys, fieldName)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// ValidationError describes one way a Document failed to conform to a
+// Schema. Row is -1 for block-level errors (a missing block or a kind
+// mismatch).
+type ValidationError struct {
+	Block   string
+	Row     int
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf("ison: %s: %s", e.Block, e.Message)
+	}
+	return fmt.Sprintf("ison: %s row %d field %q: %s", e.Block, e.Row, e.Field, e.Message)
+}
+
+// Validate checks doc against schema: block presence and kind, field
+// presence, type-hint conformance, null handling, referential integrity of
+// Reference values against their target block's rows, and uniqueness of
+// each block's declared primary key. It returns every violation found,
+// rather than stopping at the first.
+func (d *Document) Validate(schema *Schema) []ValidationError {
+	var errs []ValidationError
+	resolver := NewResolver(d)
+
+	for _, bs := range schema.Blocks {
+		block, ok := d.Get(bs.Name)
+		if !ok {
+			errs = append(errs, ValidationError{Block: bs.Name, Row: -1, Message: "required block not present"})
+			continue
+		}
+		if bs.Kind != "" && block.Kind != bs.Kind {
+			errs = append(errs, ValidationError{
+				Block: bs.Name, Row: -1,
+				Message: fmt.Sprintf("expected kind %q, got %q", bs.Kind, block.Kind),
+			})
+		}
+
+		seenKeys := make(map[string]bool, len(block.Rows))
+		for i, row := range block.Rows {
+			for _, fs := range bs.Fields {
+				errs = append(errs, validateField(bs.Name, i, fs, row, resolver)...)
+			}
+			if len(bs.Keys) == 0 {
+				continue
+			}
+			key := compositeKey(row, bs.Keys)
+			if seenKeys[key] {
+				errs = append(errs, ValidationError{
+					Block: bs.Name, Row: i, Field: strings.Join(bs.Keys, ","),
+					Message: "duplicate primary key value",
+				})
+			}
+			seenKeys[key] = true
+		}
+	}
+	return errs
+}
+
+func validateField(blockName string, rowIdx int, fs FieldSchema, row Row, resolver *Resolver) []ValidationError {
+	v, present := row[fs.Name]
+	if !present || v.IsNull() {
+		if !fs.Nullable {
+			return []ValidationError{{Block: blockName, Row: rowIdx, Field: fs.Name, Message: "required field is missing or null"}}
+		}
+		return nil
+	}
+
+	if fs.Type == "ref" {
+		ref, ok := v.AsRef()
+		if !ok {
+			return []ValidationError{{Block: blockName, Row: rowIdx, Field: fs.Name, Message: "expected a reference value"}}
+		}
+		if fs.Ref == "" {
+			return nil
+		}
+		if _, ok := resolver.byBlock[fs.Ref][ref.ID]; !ok {
+			return []ValidationError{{
+				Block: blockName, Row: rowIdx, Field: fs.Name,
+				Message: fmt.Sprintf("dangling reference %s into %q", ref.ToISON(), fs.Ref),
+			}}
+		}
+		return nil
+	}
+
+	if fs.Type != "" && !valueMatchesType(v, fs.Type) {
+		return []ValidationError{{
+			Block: blockName, Row: rowIdx, Field: fs.Name,
+			Message: fmt.Sprintf("expected type %q, got %s", fs.Type, valueTypeName(v.Type)),
+		}}
+	}
+	return nil
+}
+
+func valueMatchesType(v Value, typeHint string) bool {
+	switch typeHint {
+	case "int":
+		return v.Type == TypeInt
+	case "float":
+		return v.Type == TypeFloat || v.Type == TypeInt
+	case "string":
+		return v.Type == TypeString
+	case "bool":
+		return v.Type == TypeBool
+	case "semver":
+		return v.Type == TypeSemver
+	default:
+		return true
+	}
+}
+
+func valueTypeName(t ValueType) string {
+	switch t {
+	case TypeNull:
+		return "null"
+	case TypeBool:
+		return "bool"
+	case TypeInt:
+		return "int"
+	case TypeFloat:
+		return "float"
+	case TypeString:
+		return "string"
+	case TypeReference:
+		return "ref"
+	case TypeSemver:
+		return "semver"
+	default:
+		return "unknown"
+	}
+}
+
+func compositeKey(row Row, fields []string) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = valueKey(row[f])
+	}
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,160 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func schemaTestSchema() *Schema {
+	schema := NewSchema()
+	schema.AddBlock("users", "table").
+		Field("id", "int", false).
+		Field("name", "string", false).
+		PrimaryKey("id")
+	schema.AddBlock("orders", "table").
+		Field("id", "int", false).
+		RefField("user_id", "users", false).
+		Field("note", "string", true).
+		PrimaryKey("id")
+	return schema
+}
+
+func schemaTestDoc() *Document {
+	doc := NewDocument()
+
+	users := NewBlock("table", "users")
+	users.AddField("id", "int")
+	users.AddField("name", "string")
+	users.AddRow(Row{"id": Int(1), "name": String("Alice")})
+	users.AddRow(Row{"id": Int(2), "name": String("Bob")})
+	doc.AddBlock(users)
+
+	orders := NewBlock("table", "orders")
+	orders.AddField("id", "int")
+	orders.AddField("user_id", "ref")
+	orders.AddField("note", "string")
+	orders.AddRow(Row{"id": Int(1), "user_id": Ref(Reference{ID: "1", Namespace: "user"}), "note": Null()})
+	doc.AddBlock(orders)
+
+	return doc
+}
+
+func TestValidatePassesForConformingDocument(t *testing.T) {
+	doc := schemaTestDoc()
+	errs := doc.Validate(schemaTestSchema())
+	assert.Empty(t, errs)
+}
+
+func TestValidateReportsMissingBlock(t *testing.T) {
+	doc := NewDocument()
+	errs := doc.Validate(schemaTestSchema())
+	require.Len(t, errs, 2)
+	assert.Equal(t, -1, errs[0].Row)
+}
+
+func TestValidateReportsMissingRequiredField(t *testing.T) {
+	doc := schemaTestDoc()
+	users, _ := doc.Get("users")
+	delete(users.Rows[0], "name")
+
+	errs := doc.Validate(schemaTestSchema())
+	require.Len(t, errs, 1)
+	assert.Equal(t, "name", errs[0].Field)
+}
+
+func TestValidateAllowsNullForNullableField(t *testing.T) {
+	doc := schemaTestDoc()
+	errs := doc.Validate(schemaTestSchema())
+	assert.Empty(t, errs)
+}
+
+func TestValidateReportsTypeMismatch(t *testing.T) {
+	doc := schemaTestDoc()
+	users, _ := doc.Get("users")
+	users.Rows[1]["id"] = String("not-an-int")
+
+	errs := doc.Validate(schemaTestSchema())
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "expected type")
+}
+
+func TestValidateReportsDanglingReference(t *testing.T) {
+	doc := schemaTestDoc()
+	orders, _ := doc.Get("orders")
+	orders.Rows[0]["user_id"] = Ref(Reference{ID: "99", Namespace: "user"})
+
+	errs := doc.Validate(schemaTestSchema())
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "dangling reference")
+}
+
+func TestValidateReportsDuplicatePrimaryKey(t *testing.T) {
+	doc := schemaTestDoc()
+	users, _ := doc.Get("users")
+	users.Rows[1]["id"] = Int(1)
+
+	errs := doc.Validate(schemaTestSchema())
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "duplicate primary key")
+}
+
+func TestSchemaFromDocumentParsesSchemaBlocks(t *testing.T) {
+	input := `
+schema.users
+field type nullable key ref
+id int false true
+name string false false
+
+schema.orders
+field type nullable key ref
+id int false true
+user_id ref false false users
+`
+	doc, err := Parse(input)
+	require.NoError(t, err)
+
+	schema, err := SchemaFromDocument(doc)
+	require.NoError(t, err)
+	require.Len(t, schema.Blocks, 2)
+
+	var usersSchema, ordersSchema *BlockSchema
+	for i := range schema.Blocks {
+		switch schema.Blocks[i].Name {
+		case "users":
+			usersSchema = &schema.Blocks[i]
+		case "orders":
+			ordersSchema = &schema.Blocks[i]
+		}
+	}
+	require.NotNil(t, usersSchema)
+	require.NotNil(t, ordersSchema)
+	assert.Equal(t, []string{"id"}, usersSchema.Keys)
+
+	require.Len(t, ordersSchema.Fields, 2)
+	assert.Equal(t, "ref", ordersSchema.Fields[1].Type)
+	assert.Equal(t, "users", ordersSchema.Fields[1].Ref)
+}
+
+func TestParseSchemaValidatesAgainstData(t *testing.T) {
+	schemaText := `
+schema.users
+field type nullable key ref
+id int false true
+name string false false
+`
+	dataText := `
+table.users
+id name
+1 Alice
+`
+	schema, err := ParseSchema(schemaText)
+	require.NoError(t, err)
+
+	doc, err := Parse(dataText)
+	require.NoError(t, err)
+
+	errs := doc.Validate(schema)
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,283 @@
+package ison
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Semver holds the parsed components of a semantic version, following
+// semver.org precedence rules: major.minor.patch, an optional
+// dot-separated prerelease, and an optional build metadata string that is
+// preserved for round-tripping but ignored by Compare.
+type Semver struct {
+	Major      int64
+	Minor      int64
+	Patch      int64
+	Prerelease string
+	Build      string
+}
+
+var semverPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// ParseSemver parses a textual semantic version such as "1.2.3-rc.1+build.5".
+func ParseSemver(s string) (Semver, error) {
+	m := semverPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Semver{}, fmt.Errorf("ison: invalid semver %q", s)
+	}
+
+	major, _ := strconv.ParseInt(m[1], 10, 64)
+	minor, _ := strconv.ParseInt(m[2], 10, 64)
+	patch, _ := strconv.ParseInt(m[3], 10, 64)
+
+	return Semver{Major: major, Minor: minor, Patch: patch, Prerelease: m[4], Build: m[5]}, nil
+}
+
+// String renders sv back to its textual form, round-tripping ParseSemver.
+func (sv Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, sv.Patch)
+	if sv.Prerelease != "" {
+		s += "-" + sv.Prerelease
+	}
+	if sv.Build != "" {
+		s += "+" + sv.Build
+	}
+	return s
+}
+
+// Compare reports sv's precedence relative to other: -1 if sv < other, 0 if
+// equal, 1 if sv > other. Build metadata is ignored, as required by the
+// semver spec.
+func (sv Semver) Compare(other Semver) int {
+	if c := cmpInt64(sv.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmpInt64(sv.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmpInt64(sv.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(sv.Prerelease, other.Prerelease)
+}
+
+// Next returns the version obtained by bumping part ("major", "minor", or
+// "patch"), resetting the less-significant components and dropping any
+// prerelease/build metadata, same as `npm version`/`cargo bump`.
+func (sv Semver) Next(part string) Semver {
+	switch part {
+	case "major":
+		return Semver{Major: sv.Major + 1}
+	case "minor":
+		return Semver{Major: sv.Major, Minor: sv.Minor + 1}
+	case "patch":
+		return Semver{Major: sv.Major, Minor: sv.Minor, Patch: sv.Patch + 1}
+	default:
+		return sv
+	}
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease implements semver's prerelease precedence: no
+// prerelease outranks any prerelease; otherwise identifiers are compared
+// dot-segment by dot-segment, numeric segments compared numerically,
+// alphanumeric segments lexically, and numeric segments always rank below
+// alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	if a == "" && b == "" {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := comparePrereleaseIdentifier(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return cmpInt64(int64(len(aParts)), int64(len(bParts)))
+}
+
+func comparePrereleaseIdentifier(a, b string) int {
+	an, aNum := parseNumericIdentifier(a)
+	bn, bNum := parseNumericIdentifier(b)
+	switch {
+	case aNum && bNum:
+		return cmpInt64(an, bn)
+	case aNum && !bNum:
+		return -1
+	case !aNum && bNum:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseNumericIdentifier(s string) (int64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// CompareSemver compares v against other as semvers, erroring if either
+// Value isn't a Semver.
+func (v Value) CompareSemver(other Value) (int, error) {
+	a, ok := v.AsSemver()
+	if !ok {
+		return 0, fmt.Errorf("ison: CompareSemver: %v is not a semver value", v.Interface())
+	}
+	b, ok := other.AsSemver()
+	if !ok {
+		return 0, fmt.Errorf("ison: CompareSemver: %v is not a semver value", other.Interface())
+	}
+	return a.Compare(b), nil
+}
+
+// semverComparator is one clause of a SemverRange, e.g. ">= 1.2.3".
+type semverComparator struct {
+	op      string
+	version Semver
+}
+
+func (c semverComparator) matches(sv Semver) bool {
+	cmp := sv.Compare(c.version)
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "==":
+		return cmp == 0
+	default:
+		return false
+	}
+}
+
+// SemverRange is a set of semver comparator clauses that must all match,
+// supporting the common range shorthands: "^1.2.3", "~1.2.3", ">=1.2.3",
+// "<2.0.0", and hyphen ranges ("1.2.3 - 2.3.4").
+type SemverRange struct {
+	comparators []semverComparator
+}
+
+// ParseSemverRange parses a range expression into a SemverRange.
+func ParseSemverRange(expr string) (SemverRange, error) {
+	expr = strings.TrimSpace(expr)
+
+	if idx := strings.Index(expr, " - "); idx >= 0 {
+		low, err := ParseSemver(strings.TrimSpace(expr[:idx]))
+		if err != nil {
+			return SemverRange{}, err
+		}
+		high, err := ParseSemver(strings.TrimSpace(expr[idx+len(" - "):]))
+		if err != nil {
+			return SemverRange{}, err
+		}
+		return SemverRange{comparators: []semverComparator{
+			{op: ">=", version: low},
+			{op: "<=", version: high},
+		}}, nil
+	}
+
+	if strings.HasPrefix(expr, "^") {
+		base, err := ParseSemver(expr[1:])
+		if err != nil {
+			return SemverRange{}, err
+		}
+		return SemverRange{comparators: []semverComparator{
+			{op: ">=", version: base},
+			{op: "<", version: caretCeiling(base)},
+		}}, nil
+	}
+
+	if strings.HasPrefix(expr, "~") {
+		base, err := ParseSemver(expr[1:])
+		if err != nil {
+			return SemverRange{}, err
+		}
+		return SemverRange{comparators: []semverComparator{
+			{op: ">=", version: base},
+			{op: "<", version: Semver{Major: base.Major, Minor: base.Minor + 1}},
+		}}, nil
+	}
+
+	for _, op := range []string{">=", "<=", "==", "<", ">"} {
+		if strings.HasPrefix(expr, op) {
+			v, err := ParseSemver(strings.TrimSpace(expr[len(op):]))
+			if err != nil {
+				return SemverRange{}, err
+			}
+			return SemverRange{comparators: []semverComparator{{op: op, version: v}}}, nil
+		}
+	}
+
+	v, err := ParseSemver(expr)
+	if err != nil {
+		return SemverRange{}, err
+	}
+	return SemverRange{comparators: []semverComparator{{op: "==", version: v}}}, nil
+}
+
+// caretCeiling returns the first version that no longer satisfies "^base":
+// the next major version, unless major is 0, in which case it's the next
+// minor (or, for 0.0.x, the next patch) — npm's "won't break 0.x" rule.
+func caretCeiling(base Semver) Semver {
+	switch {
+	case base.Major > 0:
+		return Semver{Major: base.Major + 1}
+	case base.Minor > 0:
+		return Semver{Minor: base.Minor + 1}
+	default:
+		return Semver{Patch: base.Patch + 1}
+	}
+}
+
+// Contains reports whether v is a Semver value satisfying every clause in r.
+func (r SemverRange) Contains(v Value) bool {
+	sv, ok := v.AsSemver()
+	if !ok {
+		return false
+	}
+	for _, c := range r.comparators {
+		if !c.matches(sv) {
+			return false
+		}
+	}
+	return true
+}
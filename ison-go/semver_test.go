@@ -0,0 +1,153 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemverRoundTrips(t *testing.T) {
+	sv, err := ParseSemver("1.2.3-rc.1+build.5")
+	require.NoError(t, err)
+	assert.Equal(t, Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "build.5"}, sv)
+	assert.Equal(t, "1.2.3-rc.1+build.5", sv.String())
+}
+
+func TestParseSemverRejectsGarbage(t *testing.T) {
+	_, err := ParseSemver("not-a-version")
+	assert.Error(t, err)
+}
+
+func TestSemverComparePrecedence(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "2.0.0", -1},
+		{"2.0.0", "1.0.0", 1},
+		{"1.2.3", "1.2.3", 0},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0", "1.0.0-alpha", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+		{"1.0.0+build1", "1.0.0+build2", 0},
+	}
+	for _, c := range cases {
+		a, err := ParseSemver(c.a)
+		require.NoError(t, err)
+		b, err := ParseSemver(c.b)
+		require.NoError(t, err)
+		assert.Equal(t, c.want, a.Compare(b), "%s vs %s", c.a, c.b)
+	}
+}
+
+func TestValueCompareSemver(t *testing.T) {
+	a, _ := ParseSemver("1.2.3")
+	b, _ := ParseSemver("1.3.0")
+	cmp, err := SemverValue(a).CompareSemver(SemverValue(b))
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = SemverValue(a).CompareSemver(String("not a semver"))
+	assert.Error(t, err)
+}
+
+func TestSemverNext(t *testing.T) {
+	sv, _ := ParseSemver("1.2.3-rc.1")
+	assert.Equal(t, Semver{Major: 2}, sv.Next("major"))
+	assert.Equal(t, Semver{Major: 1, Minor: 3}, sv.Next("minor"))
+	assert.Equal(t, Semver{Major: 1, Minor: 2, Patch: 4}, sv.Next("patch"))
+}
+
+func TestSemverRangeCaret(t *testing.T) {
+	r, err := ParseSemverRange("^1.2.3")
+	require.NoError(t, err)
+
+	inRange, _ := ParseSemver("1.9.0")
+	outOfRange, _ := ParseSemver("2.0.0")
+	tooLow, _ := ParseSemver("1.2.2")
+
+	assert.True(t, r.Contains(SemverValue(inRange)))
+	assert.False(t, r.Contains(SemverValue(outOfRange)))
+	assert.False(t, r.Contains(SemverValue(tooLow)))
+}
+
+func TestSemverRangeCaretZeroMajor(t *testing.T) {
+	r, err := ParseSemverRange("^0.2.3")
+	require.NoError(t, err)
+
+	inRange, _ := ParseSemver("0.2.9")
+	outOfRange, _ := ParseSemver("0.3.0")
+
+	assert.True(t, r.Contains(SemverValue(inRange)))
+	assert.False(t, r.Contains(SemverValue(outOfRange)))
+}
+
+func TestSemverRangeTilde(t *testing.T) {
+	r, err := ParseSemverRange("~1.2.3")
+	require.NoError(t, err)
+
+	inRange, _ := ParseSemver("1.2.9")
+	outOfRange, _ := ParseSemver("1.3.0")
+
+	assert.True(t, r.Contains(SemverValue(inRange)))
+	assert.False(t, r.Contains(SemverValue(outOfRange)))
+}
+
+func TestSemverRangeComparators(t *testing.T) {
+	r, err := ParseSemverRange(">=1.2.3")
+	require.NoError(t, err)
+
+	higher, _ := ParseSemver("1.2.4")
+	lower, _ := ParseSemver("1.2.2")
+	assert.True(t, r.Contains(SemverValue(higher)))
+	assert.False(t, r.Contains(SemverValue(lower)))
+}
+
+func TestSemverRangeHyphen(t *testing.T) {
+	r, err := ParseSemverRange("1.2.3 - 2.3.4")
+	require.NoError(t, err)
+
+	inRange, _ := ParseSemver("2.0.0")
+	outOfRange, _ := ParseSemver("2.4.0")
+	assert.True(t, r.Contains(SemverValue(inRange)))
+	assert.False(t, r.Contains(SemverValue(outOfRange)))
+}
+
+func TestFromDictWithOptionsDetectsSemver(t *testing.T) {
+	data := map[string]interface{}{
+		"packages": []interface{}{
+			map[string]interface{}{"name": "lib", "version": "1.2.3"},
+		},
+	}
+
+	doc := FromDictWithOptions(data, FromDictOptions{DetectSemver: true})
+	block, ok := doc.Get("packages")
+	require.True(t, ok)
+
+	sv, ok := block.Rows[0]["version"].AsSemver()
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3", sv.String())
+}
+
+func TestFromDictWithOptionsSemverDetectionIsOptIn(t *testing.T) {
+	data := map[string]interface{}{
+		"packages": []interface{}{
+			map[string]interface{}{"version": "1.2.3"},
+		},
+	}
+
+	doc := FromDictWithOptions(data, DefaultFromDictOptions())
+	block, _ := doc.Get("packages")
+	_, isStr := block.Rows[0]["version"].AsString()
+	assert.True(t, isStr)
+}
+
+func TestParseValueSemverTypeHint(t *testing.T) {
+	v := parseValue("1.2.3", "semver")
+	sv, ok := v.AsSemver()
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3", sv.String())
+}
@@ -0,0 +1,208 @@
+package ison
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// Unmarshal decodes ISON text using `ison` struct tags, the way
+// encoding/json.Unmarshal uses `json` tags. v must be a pointer to a slice
+// of structs, decoding a single `table.<name>` block; a struct whose fields
+// are tagged `ison:",block=<name>"`, decoding a full multi-block Document;
+// or any other struct, decoding the first row of the block named for its
+// type (or of the document's only block).
+func Unmarshal(data []byte, v interface{}) error {
+	doc, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	return unmarshalDocument(doc, v)
+}
+
+// unmarshalDocument is the Document-based core of Unmarshal, also used
+// directly by ToStruct.
+func unmarshalDocument(doc *Document, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("ison: Unmarshal requires a non-nil pointer, got %T", v)
+	}
+	elem := rv.Elem()
+
+	switch elem.Kind() {
+	case reflect.Slice:
+		block, err := soleBlock(doc, tableNameFor(elem.Type().Elem()))
+		if err != nil {
+			return err
+		}
+		return decodeSlice(block, elem)
+
+	case reflect.Struct:
+		fields := blockFields(elem.Type())
+		if len(fields) == 0 {
+			block, err := soleBlock(doc, objectNameFor(elem.Type()))
+			if err != nil {
+				return err
+			}
+			return decodeStructFromRow(block, elem)
+		}
+
+		for _, bf := range fields {
+			block, ok := doc.Get(bf.name)
+			if !ok {
+				continue
+			}
+			fv := elem.Field(bf.index)
+			if fv.Kind() == reflect.Slice {
+				if err := decodeSlice(block, fv); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decodeStructFromRow(block, fv); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("ison: Unmarshal requires a pointer to a struct or slice of structs, got %s", elem.Kind())
+	}
+}
+
+// soleBlock looks up a block by name, falling back to the document's only
+// block if there isn't one by that name, so callers aren't forced to name
+// their type after the exact block in the source text.
+func soleBlock(doc *Document, name string) (*Block, error) {
+	if block, ok := doc.Get(name); ok {
+		return block, nil
+	}
+	if len(doc.Order) == 1 {
+		return doc.Blocks[doc.Order[0]], nil
+	}
+	return nil, fmt.Errorf("ison: no block named %q in document", name)
+}
+
+func decodeSlice(block *Block, elem reflect.Value) error {
+	elemType := derefType(elem.Type().Elem())
+	codec := getCodec(elemType)
+
+	slice := reflect.MakeSlice(elem.Type(), len(block.Rows), len(block.Rows))
+	for i, row := range block.Rows {
+		dst := slice.Index(i)
+		target := dst
+		if dst.Kind() == reflect.Ptr {
+			target = reflect.New(dst.Type().Elem())
+			dst.Set(target)
+			target = target.Elem()
+		}
+		if err := decodeRow(codec, row, target); err != nil {
+			return err
+		}
+	}
+	elem.Set(slice)
+	return nil
+}
+
+func decodeStructFromRow(block *Block, elem reflect.Value) error {
+	if len(block.Rows) == 0 {
+		return nil
+	}
+	codec := getCodec(derefType(elem.Type()))
+	return decodeRow(codec, block.Rows[0], derefValue(elem))
+}
+
+func decodeRow(codec *structCodec, row Row, rv reflect.Value) error {
+	for _, f := range codec.fields {
+		val, ok := row[f.name]
+		if !ok || val.IsNull() {
+			continue
+		}
+		if err := decodeFieldValue(val, rv.FieldByIndex(f.index), f); err != nil {
+			return fmt.Errorf("ison: field %q: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func decodeFieldValue(val Value, fv reflect.Value, f codecField) error {
+	if f.ref != "" {
+		ref, ok := val.AsRef()
+		if !ok {
+			return fmt.Errorf("cannot decode %v into a reference", val.Interface())
+		}
+		return setScalar(fv, ref.ID)
+	}
+
+	if tu, ok := textUnmarshaler(fv); ok {
+		s, _ := val.AsString()
+		return tu.UnmarshalText([]byte(s))
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		target := reflect.New(fv.Type().Elem())
+		if err := decodeFieldValue(val, target.Elem(), f); err != nil {
+			return err
+		}
+		fv.Set(target)
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		s, ok := val.AsString()
+		if !ok {
+			return fmt.Errorf("cannot decode %v into a string", val.Interface())
+		}
+		fv.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := val.AsInt()
+		if !ok {
+			return fmt.Errorf("cannot decode %v into an int", val.Interface())
+		}
+		fv.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		n, ok := val.AsFloat()
+		if !ok {
+			return fmt.Errorf("cannot decode %v into a float", val.Interface())
+		}
+		fv.SetFloat(n)
+	case reflect.Bool:
+		b, ok := val.AsBool()
+		if !ok {
+			return fmt.Errorf("cannot decode %v into a bool", val.Interface())
+		}
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("cannot decode into %s", fv.Type())
+	}
+	return nil
+}
+
+// setScalar assigns a string-form value (e.g. a Reference's ID) into a
+// string or integer field, used for `ref=` tagged fields.
+func setScalar(fv reflect.Value, s string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := parseValue(s, "int")
+		i, ok := v.AsInt()
+		if !ok {
+			return fmt.Errorf("cannot decode reference id %q into an int", s)
+		}
+		fv.SetInt(i)
+		return nil
+	default:
+		return fmt.Errorf("cannot decode reference id into %s", fv.Type())
+	}
+}
+
+func textUnmarshaler(fv reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if !fv.CanAddr() {
+		return nil, false
+	}
+	tu, ok := fv.Addr().Interface().(encoding.TextUnmarshaler)
+	return tu, ok
+}
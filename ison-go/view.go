@@ -0,0 +1,325 @@
+package ison
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ViewStepKind identifies one stage of a View's transform pipeline.
+type ViewStepKind string
+
+const (
+	ViewSort    ViewStepKind = "sort"
+	ViewFilter  ViewStepKind = "filter"
+	ViewProject ViewStepKind = "project"
+	ViewJoin    ViewStepKind = "join"
+)
+
+// ViewStep is one stage of a View's pipeline. Which fields are meaningful
+// depends on Kind: Sort/Filter use Expr, Project uses Fields, Join uses
+// Table and On.
+type ViewStep struct {
+	Kind   ViewStepKind
+	Expr   string   // sort key ("field" or "field desc") or filter predicate
+	Fields []string // project: the fields to keep, in order
+	Table  string   // join: the other table to join against
+	On     string   // join: the field in the current rows holding the reference
+}
+
+// View is a declarative pipeline that derives a table from another table
+// (or another view) without requiring a Go callback: Sort, Filter, Project
+// and Join steps are described as data, and FromDictWithOptions builds
+// views from a "views" entry. Views are not read from or written to ISON
+// text, and ToDict does not emit them. Call
+// Document.MaterializeViews to evaluate every view into a concrete table
+// Block.
+type View struct {
+	Name  string
+	Base  string
+	Steps []ViewStep
+}
+
+// NewView creates an empty view over base, ready for Sort/Filter/Project/
+// Join calls to build up its pipeline.
+func NewView(name, base string) *View {
+	return &View{Name: name, Base: base}
+}
+
+// Sort appends a sort step. expr is a field name, optionally followed by
+// "asc" or "desc" (default ascending), e.g. "published_at desc".
+func (v *View) Sort(expr string) *View {
+	v.Steps = append(v.Steps, ViewStep{Kind: ViewSort, Expr: expr})
+	return v
+}
+
+// Filter appends a filter step. expr is a small predicate like
+// `len(title) > 16` or `status == "active"`.
+func (v *View) Filter(expr string) *View {
+	v.Steps = append(v.Steps, ViewStep{Kind: ViewFilter, Expr: expr})
+	return v
+}
+
+// Project appends a step that narrows rows down to fields, in order.
+func (v *View) Project(fields ...string) *View {
+	v.Steps = append(v.Steps, ViewStep{Kind: ViewProject, Fields: fields})
+	return v
+}
+
+// Join appends a step that looks up, for each row, the other table's row
+// whose "id" matches the reference held in field on, and merges its fields
+// in under a "<table>.<field>" name.
+func (v *View) Join(table, on string) *View {
+	v.Steps = append(v.Steps, ViewStep{Kind: ViewJoin, Table: table, On: on})
+	return v
+}
+
+// NewSortView creates a view that sorts base by expr.
+func NewSortView(name, base, expr string) *View {
+	return NewView(name, base).Sort(expr)
+}
+
+// NewFilterView creates a view that filters base by expr.
+func NewFilterView(name, base, expr string) *View {
+	return NewView(name, base).Filter(expr)
+}
+
+// NewProjectView creates a view that projects base down to fields.
+func NewProjectView(name, base string, fields ...string) *View {
+	return NewView(name, base).Project(fields...)
+}
+
+// NewJoinView creates a view that joins base to table via the reference
+// held in field on.
+func NewJoinView(name, base, table, on string) *View {
+	return NewView(name, base).Join(table, on)
+}
+
+// viewFromDict builds a View from a "views" entry produced by FromDict's
+// generic JSON-shaped input, e.g.:
+//
+//	{"base": "posts", "filter": "len(title) > 16", "sort": "published_at desc"}
+//
+// Steps are applied in a fixed canonical order (filter, sort, project,
+// join) regardless of key order, since Go map iteration isn't ordered.
+func viewFromDict(name string, spec map[string]interface{}) *View {
+	base, _ := spec["base"].(string)
+	view := NewView(name, base)
+
+	if expr, ok := spec["filter"].(string); ok {
+		view.Filter(expr)
+	}
+	if expr, ok := spec["sort"].(string); ok {
+		view.Sort(expr)
+	}
+	if fields, ok := spec["project"].([]interface{}); ok {
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			if s, ok := f.(string); ok {
+				names = append(names, s)
+			}
+		}
+		view.Project(names...)
+	}
+	if join, ok := spec["join"].(map[string]interface{}); ok {
+		table, _ := join["table"].(string)
+		on, _ := join["on"].(string)
+		view.Join(table, on)
+	}
+
+	return view
+}
+
+// MaterializeViews evaluates every view in dependency order and adds the
+// resulting rows as concrete table Blocks, so a view can itself be the base
+// of another view. It returns an error on a dangling base table/view or a
+// dependency cycle between views.
+func (d *Document) MaterializeViews() error {
+	order, err := topoSortViews(d.Views, d.ViewOrder)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		fields, rows, err := d.Views[name].evaluate(d)
+		if err != nil {
+			return fmt.Errorf("ison: view %q: %w", name, err)
+		}
+
+		block := NewBlock("table", name)
+		for _, f := range fields {
+			block.AddField(f, "")
+		}
+		for _, row := range rows {
+			block.AddRow(row)
+		}
+		d.AddBlock(block)
+	}
+	return nil
+}
+
+// topoSortViews orders views so that any view used as another view's base
+// is materialized first, detecting cycles along the way.
+func topoSortViews(views map[string]*View, order []string) ([]string, error) {
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := make(map[string]int, len(views))
+	result := make([]string, 0, len(views))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("ison: cycle detected in view %q", name)
+		}
+		state[name] = visiting
+		if base, ok := views[name]; ok {
+			if _, baseIsView := views[base.Base]; baseIsView {
+				if err := visit(base.Base); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = done
+		result = append(result, name)
+		return nil
+	}
+
+	for _, name := range order {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// evaluate runs v's pipeline against d, returning the resulting field names
+// (in order) and rows.
+func (v *View) evaluate(d *Document) ([]string, []Row, error) {
+	base, ok := d.Get(v.Base)
+	if !ok {
+		return nil, nil, fmt.Errorf("base table %q not found", v.Base)
+	}
+
+	fields := base.GetFieldNames()
+	rows := append([]Row(nil), base.Rows...)
+
+	for _, step := range v.Steps {
+		var err error
+		switch step.Kind {
+		case ViewFilter:
+			rows, err = applyFilter(rows, step.Expr)
+		case ViewSort:
+			rows = applySort(rows, step.Expr)
+		case ViewProject:
+			fields, rows = applyProject(rows, step.Fields)
+		case ViewJoin:
+			fields, rows, err = applyJoin(d, fields, rows, step)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return fields, rows, nil
+}
+
+func applyFilter(rows []Row, expr string) ([]Row, error) {
+	filtered := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		ok, err := evalPredicate(expr, row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered, nil
+}
+
+func applySort(rows []Row, expr string) []Row {
+	field, desc := parseSortExpr(expr)
+	sorted := append([]Row(nil), rows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		less, _ := compareValues(sorted[i][field], sorted[j][field], "<")
+		if desc {
+			greater, _ := compareValues(sorted[i][field], sorted[j][field], ">")
+			return greater
+		}
+		return less
+	})
+	return sorted
+}
+
+func applyProject(rows []Row, fields []string) ([]string, []Row) {
+	projected := make([]Row, len(rows))
+	for i, row := range rows {
+		narrow := make(Row, len(fields))
+		for _, f := range fields {
+			if v, ok := row[f]; ok {
+				narrow[f] = v
+			}
+		}
+		projected[i] = narrow
+	}
+	return fields, projected
+}
+
+func applyJoin(d *Document, fields []string, rows []Row, step ViewStep) ([]string, []Row, error) {
+	other, ok := d.Get(step.Table)
+	if !ok {
+		return nil, nil, fmt.Errorf("join table %q not found", step.Table)
+	}
+
+	byID := make(map[string]Row, len(other.Rows))
+	for _, row := range other.Rows {
+		if id, ok := row["id"]; ok {
+			byID[valueKey(id)] = row
+		}
+	}
+
+	joinedFields := append([]string(nil), fields...)
+	for _, f := range other.GetFieldNames() {
+		if f == "id" {
+			continue
+		}
+		joinedFields = append(joinedFields, step.Table+"."+f)
+	}
+
+	joined := make([]Row, len(rows))
+	for i, row := range rows {
+		merged := make(Row, len(row))
+		for k, v := range row {
+			merged[k] = v
+		}
+
+		if refVal, ok := row[step.On]; ok {
+			key := refKey(refVal)
+			if match, ok := byID[key]; ok {
+				for k, v := range match {
+					if k == "id" {
+						continue
+					}
+					merged[step.Table+"."+k] = v
+				}
+			}
+		}
+		joined[i] = merged
+	}
+
+	return joinedFields, joined, nil
+}
+
+// refKey reduces a Value used as a join key down to a plain string,
+// following a Reference through to its ID when present.
+func refKey(v Value) string {
+	if ref, ok := v.AsRef(); ok {
+		return ref.ID
+	}
+	return valueKey(v)
+}
@@ -0,0 +1,139 @@
+package ison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func viewTestDoc() *Document {
+	doc := NewDocument()
+
+	posts := NewBlock("table", "posts")
+	posts.AddField("id", "int")
+	posts.AddField("title", "string")
+	posts.AddField("published_at", "int")
+	posts.AddField("author_id", "ref")
+	posts.AddRow(Row{"id": Int(1), "title": String("short"), "published_at": Int(3), "author_id": Ref(Reference{ID: "1"})})
+	posts.AddRow(Row{"id": Int(2), "title": String("a much longer title"), "published_at": Int(1), "author_id": Ref(Reference{ID: "2"})})
+	posts.AddRow(Row{"id": Int(3), "title": String("also quite a long title"), "published_at": Int(2), "author_id": Ref(Reference{ID: "1"})})
+	doc.AddBlock(posts)
+
+	authors := NewBlock("table", "authors")
+	authors.AddField("id", "int")
+	authors.AddField("name", "string")
+	authors.AddRow(Row{"id": Int(1), "name": String("Ada")})
+	authors.AddRow(Row{"id": Int(2), "name": String("Grace")})
+	doc.AddBlock(authors)
+
+	return doc
+}
+
+func TestViewFilterKeepsMatchingRows(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewFilterView("long_posts", "posts", "len(title) > 16"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, ok := doc.Get("long_posts")
+	require.True(t, ok)
+	assert.Len(t, block.Rows, 2)
+}
+
+func TestViewSortOrdersRows(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewSortView("by_published", "posts", "published_at desc"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, _ := doc.Get("by_published")
+	ids := make([]int64, len(block.Rows))
+	for i, row := range block.Rows {
+		ids[i], _ = row["id"].AsInt()
+	}
+	assert.Equal(t, []int64{1, 3, 2}, ids)
+}
+
+func TestViewProjectNarrowsFields(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewProjectView("post_titles", "posts", "id", "title"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, _ := doc.Get("post_titles")
+	assert.Equal(t, []string{"id", "title"}, block.GetFieldNames())
+	assert.NotContains(t, block.Rows[0], "published_at")
+}
+
+func TestViewJoinMergesOtherTableFields(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewJoinView("posts_with_authors", "posts", "authors", "author_id"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, _ := doc.Get("posts_with_authors")
+	name, ok := block.Rows[0]["authors.name"].AsString()
+	require.True(t, ok)
+	assert.Equal(t, "Ada", name)
+}
+
+func TestViewPipelineChainsSteps(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewView("digest", "posts").Filter("len(title) > 5").Sort("published_at asc").Project("id", "title"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, _ := doc.Get("digest")
+	assert.Equal(t, []string{"id", "title"}, block.GetFieldNames())
+	require.Len(t, block.Rows, 2)
+	firstID, _ := block.Rows[0]["id"].AsInt()
+	assert.Equal(t, int64(2), firstID)
+}
+
+func TestMaterializeViewsDetectsCycle(t *testing.T) {
+	doc := NewDocument()
+	doc.AddView(NewView("a", "b"))
+	doc.AddView(NewView("b", "a"))
+
+	err := doc.MaterializeViews()
+	require.Error(t, err)
+}
+
+func TestMaterializeViewsSupportsViewOfView(t *testing.T) {
+	doc := viewTestDoc()
+	doc.AddView(NewFilterView("long_posts", "posts", "len(title) > 16"))
+	doc.AddView(NewSortView("long_posts_sorted", "long_posts", "published_at asc"))
+
+	require.NoError(t, doc.MaterializeViews())
+
+	block, ok := doc.Get("long_posts_sorted")
+	require.True(t, ok)
+	require.Len(t, block.Rows, 2)
+	firstID, _ := block.Rows[0]["id"].AsInt()
+	assert.Equal(t, int64(2), firstID)
+}
+
+func TestFromDictWithOptionsParsesViews(t *testing.T) {
+	data := map[string]interface{}{
+		"posts": []interface{}{
+			map[string]interface{}{"id": 1.0, "title": "short"},
+			map[string]interface{}{"id": 2.0, "title": "a much longer title"},
+		},
+		"views": map[string]interface{}{
+			"long_posts": map[string]interface{}{
+				"base":   "posts",
+				"filter": "len(title) > 16",
+			},
+		},
+	}
+
+	doc := FromDictWithOptions(data, DefaultFromDictOptions())
+	require.Contains(t, doc.Views, "long_posts")
+	assert.Equal(t, "posts", doc.Views["long_posts"].Base)
+
+	require.NoError(t, doc.MaterializeViews())
+	block, ok := doc.Get("long_posts")
+	require.True(t, ok)
+	assert.Len(t, block.Rows, 1)
+}
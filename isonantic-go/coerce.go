@@ -0,0 +1,152 @@
+package isonantic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Coercer is implemented by schemas that support a Coerce() mode, converting
+// compatible inputs (e.g. a JSON-decoded float64, or a numeric string) into
+// the schema's canonical representation before constraint checks run.
+// CoerceValue returns the input unchanged if the schema's Coerce() mode is
+// off, or if value is already of a type the schema accepts natively, except
+// that an Int() schema turns a whole-valued float64 into an int64.
+type Coercer interface {
+	CoerceValue(value interface{}) (interface{}, error)
+}
+
+// CoerceValue converts value into a string if Coerce() is enabled.
+func (s *StringSchema) CoerceValue(value interface{}) (interface{}, error) {
+	if !s.coerce || value == nil {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		return value, nil
+	}
+}
+
+// CoerceValue converts value into a float64/int64 if Coerce() is enabled.
+func (s *NumberSchema) CoerceValue(value interface{}) (interface{}, error) {
+	if !s.coerce || value == nil {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.isInt && v == float64(int64(v)) {
+			return int64(v), nil
+		}
+		return v, nil
+	case int64, int:
+		return v, nil
+	case json.Number:
+		if s.isInt {
+			if n, err := v.Int64(); err == nil {
+				return n, nil
+			}
+		}
+		return v.Float64()
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return n, nil
+		}
+		f, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return value, fmt.Errorf("cannot coerce %q to a number", v)
+		}
+		return f, nil
+	default:
+		return value, nil
+	}
+}
+
+// CoerceValue converts value into a bool if Coerce() is enabled.
+func (s *BooleanSchema) CoerceValue(value interface{}) (interface{}, error) {
+	if !s.coerce || value == nil {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1", "yes":
+			return true, nil
+		case "false", "0", "no":
+			return false, nil
+		default:
+			return value, fmt.Errorf("cannot coerce %q to a boolean", v)
+		}
+	case float64:
+		if v == 1 {
+			return true, nil
+		}
+		if v == 0 {
+			return false, nil
+		}
+		return value, fmt.Errorf("cannot coerce %v to a boolean", v)
+	case int64:
+		return s.CoerceValue(float64(v))
+	case int:
+		return s.CoerceValue(float64(v))
+	default:
+		return value, nil
+	}
+}
+
+// ParseResult is the generic counterpart to DocumentSchema's SafeParseResult,
+// usable with any Schema. Data holds the coerced value on success (the same
+// value passed to Parse when the schema has no Coerce() mode enabled).
+type ParseResult struct {
+	Success bool
+	Data    interface{}
+	Error   error
+}
+
+// Parse validates value against s, first running s's CoerceValue if it
+// implements Coercer, and returns the (possibly coerced) value on success.
+func Parse(s Schema, value interface{}) (interface{}, error) {
+	if coercer, ok := s.(Coercer); ok {
+		coerced, err := coercer.CoerceValue(value)
+		if err == nil {
+			value = coerced
+		}
+	}
+
+	if err := s.Validate(value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// SafeParse validates value against s without returning an error, mirroring
+// DocumentSchema.SafeParse for any Schema.
+func SafeParse(s Schema, value interface{}) ParseResult {
+	data, err := Parse(s, value)
+	if err != nil {
+		return ParseResult{Success: false, Error: err}
+	}
+	return ParseResult{Success: true, Data: data}
+}
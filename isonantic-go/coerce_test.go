@@ -0,0 +1,74 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntCoercesJSONFloat(t *testing.T) {
+	schema := Int().Coerce()
+
+	data, err := Parse(schema, float64(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), data)
+}
+
+func TestIntCoercesString(t *testing.T) {
+	schema := Int().Coerce()
+
+	data, err := Parse(schema, "42")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), data)
+}
+
+func TestStringCoercesNumber(t *testing.T) {
+	schema := String().Coerce()
+
+	data, err := Parse(schema, float64(42))
+	require.NoError(t, err)
+	assert.Equal(t, "42", data)
+}
+
+func TestBooleanCoercesStringVariants(t *testing.T) {
+	schema := Boolean().Coerce()
+
+	for _, in := range []interface{}{"true", "1", "yes"} {
+		data, err := Parse(schema, in)
+		require.NoError(t, err)
+		assert.Equal(t, true, data)
+	}
+
+	for _, in := range []interface{}{"false", "0", "no"} {
+		data, err := Parse(schema, in)
+		require.NoError(t, err)
+		assert.Equal(t, false, data)
+	}
+}
+
+func TestIntWithoutCoerceRejectsString(t *testing.T) {
+	schema := Int()
+
+	_, err := Parse(schema, "42")
+	assert.Error(t, err)
+}
+
+func TestObjectCoercesFieldsInPlace(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"age": Int().Coerce(),
+	})
+
+	obj := map[string]interface{}{"age": "42"}
+	err := schema.Validate(obj)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), obj["age"])
+}
+
+func TestSafeParseReportsCoercedData(t *testing.T) {
+	schema := Int().Coerce()
+
+	result := SafeParse(schema, "7")
+	assert.True(t, result.Success)
+	assert.Equal(t, int64(7), result.Data)
+}
@@ -0,0 +1,49 @@
+package isonantic
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EnumSchema validates that a value deep-equals one of a fixed set of
+// literal values, e.g. Enum("draft", "published", "archived") for a status
+// column restricted to a small set of strings.
+type EnumSchema struct {
+	BaseSchema
+	values []interface{}
+}
+
+// Enum creates a schema that accepts only the given literal values.
+func Enum(values ...interface{}) *EnumSchema {
+	return &EnumSchema{values: values}
+}
+
+// Optional marks as optional
+func (s *EnumSchema) Optional() *EnumSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *EnumSchema) Describe(desc string) *EnumSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate checks that value deep-equals one of s's allowed values.
+func (s *EnumSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	for _, v := range s.values {
+		if reflect.DeepEqual(v, value) {
+			return s.runRefinements(value)
+		}
+	}
+
+	return fmt.Errorf("value %v is not one of the allowed values %v", value, s.values)
+}
@@ -0,0 +1,28 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumAcceptsAllowedValues(t *testing.T) {
+	schema := Enum("draft", "published", "archived")
+
+	assert.NoError(t, schema.Validate("draft"))
+	assert.NoError(t, schema.Validate("archived"))
+	assert.Error(t, schema.Validate("deleted"))
+}
+
+func TestEnumDeepEqualityForNonStrings(t *testing.T) {
+	schema := Enum(int64(1), int64(2), int64(3))
+
+	assert.NoError(t, schema.Validate(int64(2)))
+	assert.Error(t, schema.Validate(int64(4)))
+	assert.Error(t, schema.Validate("2"))
+}
+
+func TestEnumOptional(t *testing.T) {
+	schema := Enum("a", "b").Optional()
+	assert.NoError(t, schema.Validate(nil))
+}
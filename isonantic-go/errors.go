@@ -0,0 +1,195 @@
+package isonantic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemaValidationSettings configures how Object/Array/Table/Document
+// validators accumulate errors. FailFast stops at the first error (a cheap
+// path for hot loops); the zero value aggregates every error, optionally
+// capped at MaxErrors.
+type SchemaValidationSettings struct {
+	FailFast  bool
+	MaxErrors int
+}
+
+// DefaultSchemaValidationSettings returns the aggregate mode with no cap,
+// matching the validators' historical behavior.
+func DefaultSchemaValidationSettings() SchemaValidationSettings {
+	return SchemaValidationSettings{}
+}
+
+// keepGoing reports whether error collection should continue after the most
+// recently appended error, given settings.
+func (e ValidationErrors) keepGoing(settings SchemaValidationSettings) bool {
+	if settings.FailFast {
+		return false
+	}
+	if settings.MaxErrors > 0 && len(e.Errors) >= settings.MaxErrors {
+		return false
+	}
+	return true
+}
+
+// locations derives the JSON-Schema-style InstanceLocation and
+// KeywordLocation strings for a ValidationError from its final Path and
+// Code, e.g. path=["users", "1", "email"], code="string.invalid_email" ->
+// instance="/users/1/email", keyword="#/string/invalid_email".
+// AbsoluteKeywordLocation has no separate base schema to resolve against in
+// this package, so it is currently identical to KeywordLocation.
+func locations(path []string, code string) (instance, keyword, absolute string) {
+	instance = "/" + strings.Join(path, "/")
+	if code == "" {
+		return instance, "", ""
+	}
+	keyword = "#/" + strings.ReplaceAll(code, ".", "/")
+	return instance, keyword, keyword
+}
+
+// newValidationError builds a ValidationError with its InstanceLocation and
+// KeywordLocation derived from path/code, so every construction site gets
+// the JSON-Schema-style locations for free instead of computing them by hand.
+func newValidationError(field string, path []string, code, message string, value interface{}, params map[string]interface{}) ValidationError {
+	instance, keyword, absolute := locations(path, code)
+	return ValidationError{
+		Field:                   field,
+		Path:                    path,
+		Code:                    code,
+		Message:                 NewMessage(message, code, params),
+		Value:                   value,
+		Params:                  params,
+		InstanceLocation:        instance,
+		KeywordLocation:         keyword,
+		AbsoluteKeywordLocation: absolute,
+	}
+}
+
+// ErrorMessage is the interface ValidationError.Message satisfies: a
+// rendered English sentence that also carries the Code/Params it was built
+// from, so a caller wanting structured or localized output (see Locale)
+// doesn't have to re-parse String().
+type ErrorMessage interface {
+	fmt.Stringer
+	Code() string
+	Params() map[string]interface{}
+}
+
+// stringMessage is ErrorMessage's only implementation: a plain English
+// sentence plus the code/params it was derived from.
+type stringMessage struct {
+	text   string
+	code   string
+	params map[string]interface{}
+}
+
+func (m stringMessage) String() string                 { return m.text }
+func (m stringMessage) Code() string                   { return m.code }
+func (m stringMessage) Params() map[string]interface{} { return m.params }
+
+// NewMessage builds an ErrorMessage from a rendered English sentence plus
+// the code/params that produced it. Validators assembling a ValidationError
+// by hand (rather than through newValidationError) use this directly.
+func NewMessage(text, code string, params map[string]interface{}) ErrorMessage {
+	return stringMessage{text: text, code: code, params: params}
+}
+
+var (
+	reIntParam   = regexp.MustCompile(`(-?\d+) (?:characters|items)`)
+	reFloatParam = regexp.MustCompile(`at (?:least|most) (-?[0-9.]+)$`)
+	reWordParam  = regexp.MustCompile(`expected (?:namespace|relationship) (\S+)$`)
+)
+
+// intParam extracts the leaf validators' embedded "N characters"/"N items"
+// count from msg, e.g. "string must be at least 5 characters" -> {"min": 5}.
+func intParam(msg, key string) map[string]interface{} {
+	m := reIntParam.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{key: n}
+}
+
+// floatParam extracts the trailing bound from a Number validator message
+// like "number must be at least 3.5" -> {"min": 3.5}.
+func floatParam(msg, key string) map[string]interface{} {
+	m := reFloatParam.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	f, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{key: f}
+}
+
+// wordParam extracts the expected namespace/relationship name from a
+// Reference validator message, e.g. "expected namespace user" -> {"namespace": "user"}.
+func wordParam(msg, key string) map[string]interface{} {
+	m := reWordParam.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	return map[string]interface{}{key: m[1]}
+}
+
+// classifyError derives a stable machine-readable code and the constraint
+// parameters referenced by err's message, for errors originating from the
+// leaf schema validators (String, Number, Boolean, ...), which return plain
+// errors rather than ValidationErrors.
+func classifyError(err error) (code string, params map[string]interface{}) {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "required field is missing"):
+		return "required", nil
+	case strings.Contains(msg, "at least") && strings.Contains(msg, "characters"):
+		return "string.min_length", intParam(msg, "min")
+	case strings.Contains(msg, "at most") && strings.Contains(msg, "characters"):
+		return "string.max_length", intParam(msg, "max")
+	case strings.Contains(msg, "exactly") && strings.Contains(msg, "characters"):
+		return "string.exact_length", intParam(msg, "length")
+	case strings.Contains(msg, "invalid email"):
+		return "string.invalid_email", nil
+	case strings.Contains(msg, "invalid URL"):
+		return "string.invalid_url", nil
+	case strings.Contains(msg, "does not match required pattern"):
+		return "string.pattern_mismatch", nil
+	case strings.Contains(msg, "expected integer"):
+		return "number.not_integer", nil
+	case strings.Contains(msg, "must be at least"):
+		return "number.min", floatParam(msg, "min")
+	case strings.Contains(msg, "must be at most"):
+		return "number.max", floatParam(msg, "max")
+	case strings.Contains(msg, "must be positive"):
+		return "number.not_positive", nil
+	case strings.Contains(msg, "must be negative"):
+		return "number.not_negative", nil
+	case strings.Contains(msg, "expected boolean"):
+		return "boolean.invalid_type", nil
+	case strings.Contains(msg, "expected object"):
+		return "object.invalid_type", nil
+	case strings.Contains(msg, "expected array"):
+		return "array.invalid_type", nil
+	case strings.Contains(msg, "at least") && strings.Contains(msg, "items"):
+		return "array.min_items", intParam(msg, "min")
+	case strings.Contains(msg, "at most") && strings.Contains(msg, "items"):
+		return "array.max_items", intParam(msg, "max")
+	case strings.Contains(msg, "expected reference"):
+		return "ref.invalid", nil
+	case strings.Contains(msg, "expected namespace"):
+		return "ref.wrong_namespace", wordParam(msg, "namespace")
+	case strings.Contains(msg, "expected relationship"):
+		return "ref.wrong_relationship", wordParam(msg, "relationship")
+	case strings.Contains(msg, "expected table"):
+		return "table.invalid_type", nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,132 @@
+package isonantic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectValidateFailFast(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"a": String(),
+		"b": String(),
+	}).WithSettings(SchemaValidationSettings{FailFast: true})
+
+	err := schema.Validate(map[string]interface{}{})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Len(t, verrs.Errors, 1)
+}
+
+func TestObjectValidateMaxErrors(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"a": String(),
+		"b": String(),
+		"c": String(),
+	}).WithSettings(SchemaValidationSettings{MaxErrors: 2})
+
+	err := schema.Validate(map[string]interface{}{})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Len(t, verrs.Errors, 2)
+}
+
+func TestValidationErrorHasPathAndCode(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"email": String().Email(),
+	})
+
+	err := schema.Validate(map[string]interface{}{"email": "not-an-email"})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+	assert.Equal(t, []string{"email"}, verrs.Errors[0].Path)
+	assert.Equal(t, "string.invalid_email", verrs.Errors[0].Code)
+}
+
+func TestValidationErrorsIs(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"email": String().Email(),
+	})
+
+	err := schema.Validate(map[string]interface{}{"email": "not-an-email"})
+	sentinel := ValidationError{Code: "string.invalid_email"}
+	assert.True(t, errors.Is(err, sentinel))
+
+	other := ValidationError{Code: "number.min"}
+	assert.False(t, errors.Is(err, other))
+}
+
+func TestValidationErrorParamsCarriesConstraintValue(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"name": String().Min(5),
+	})
+
+	err := schema.Validate(map[string]interface{}{"name": "ab"})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+	assert.Equal(t, "string.min_length", verrs.Errors[0].Code)
+	assert.Equal(t, map[string]interface{}{"min": 5}, verrs.Errors[0].Params)
+}
+
+func TestValidationErrorParamsForNumberBound(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"age": Number().Min(18),
+	})
+
+	err := schema.Validate(map[string]interface{}{"age": 10})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+	assert.Equal(t, "number.min", verrs.Errors[0].Code)
+	assert.Equal(t, map[string]interface{}{"min": 18.0}, verrs.Errors[0].Params)
+}
+
+func TestValidationErrorParamsForReferenceNamespace(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"owner": Reference().Namespace("user"),
+	})
+
+	err := schema.Validate(map[string]interface{}{
+		"owner": map[string]interface{}{"_ref": "1", "_namespace": "team"},
+	})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+	assert.Equal(t, "ref.wrong_namespace", verrs.Errors[0].Code)
+	assert.Equal(t, map[string]interface{}{"namespace": "user"}, verrs.Errors[0].Params)
+}
+
+func TestValidationErrorMessageExposesCodeAndParams(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"name": String().Min(5),
+	})
+
+	err := schema.Validate(map[string]interface{}{"name": "ab"})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+
+	msg := verrs.Errors[0].Message
+	assert.Equal(t, "string.min_length", msg.Code())
+	assert.Equal(t, map[string]interface{}{"min": 5}, msg.Params())
+	assert.NotEmpty(t, msg.String())
+}
+
+func TestTableRowPathIncludesIndex(t *testing.T) {
+	schema := Table("users", map[string]Schema{
+		"email": String().Email(),
+	})
+
+	err := schema.Validate([]interface{}{
+		map[string]interface{}{"email": "ok@example.com"},
+		map[string]interface{}{"email": "bad"},
+	})
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	require.Len(t, verrs.Errors, 1)
+	assert.Equal(t, []string{"1", "email"}, verrs.Errors[0].Path)
+}
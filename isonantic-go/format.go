@@ -0,0 +1,202 @@
+package isonantic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FormatFunc validates a string against a named format, returning a
+// descriptive error when the value doesn't conform.
+type FormatFunc func(string) error
+
+var (
+	formatMu       sync.RWMutex
+	formatRegistry = map[string]FormatFunc{}
+	formatDisplay  = map[string]string{
+		"email": "email",
+		"url":   "URL",
+		"uri":   "URI",
+	}
+)
+
+// RegisterFormat adds (or replaces) a named format checker, so applications
+// can validate domain-specific tokens (e.g. "ssn", "iban") without forking
+// the library.
+func RegisterFormat(name string, fn func(string) error) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+	formatRegistry[name] = fn
+}
+
+// LookupFormat returns the checker registered under name, if any.
+func LookupFormat(name string) (FormatFunc, bool) {
+	formatMu.RLock()
+	defer formatMu.RUnlock()
+	fn, ok := formatRegistry[name]
+	return fn, ok
+}
+
+// RegisterFormatPredicate adapts a simple `func(string) bool` checker (true
+// = valid) into a FormatFunc, for callers who don't need a custom error
+// message. On failure the resulting FormatFunc reports "not a valid
+// <name>".
+func RegisterFormatPredicate(name string, valid func(string) bool) {
+	RegisterFormat(name, func(s string) error {
+		if !valid(s) {
+			return fmt.Errorf("not a valid %s", name)
+		}
+		return nil
+	})
+}
+
+func formatDisplayName(name string) string {
+	if display, ok := formatDisplay[name]; ok {
+		return display
+	}
+	return name
+}
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	e164Pattern     = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+)
+
+func init() {
+	RegisterFormat("email", func(s string) error {
+		pattern := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+		if !pattern.MatchString(s) {
+			return fmt.Errorf("not a valid email address")
+		}
+		return nil
+	})
+
+	RegisterFormat("url", func(s string) error {
+		pattern := regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$`)
+		if !pattern.MatchString(s) {
+			return fmt.Errorf("not a valid URL")
+		}
+		return nil
+	})
+
+	RegisterFormat("uuid", func(s string) error {
+		if !uuidPattern.MatchString(s) {
+			return fmt.Errorf("not a valid UUID")
+		}
+		return nil
+	})
+
+	RegisterFormat("date", func(s string) error {
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return fmt.Errorf("not a valid date (expected YYYY-MM-DD)")
+		}
+		return nil
+	})
+
+	RegisterFormat("date-time", func(s string) error {
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return fmt.Errorf("not a valid RFC3339 date-time")
+		}
+		return nil
+	})
+
+	RegisterFormat("time", func(s string) error {
+		if _, err := time.Parse("15:04:05", s); err != nil {
+			return fmt.Errorf("not a valid time (expected HH:MM:SS)")
+		}
+		return nil
+	})
+
+	RegisterFormat("ipv4", func(s string) error {
+		ip := net.ParseIP(s)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("not a valid IPv4 address")
+		}
+		return nil
+	})
+
+	RegisterFormat("ipv6", func(s string) error {
+		ip := net.ParseIP(s)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("not a valid IPv6 address")
+		}
+		return nil
+	})
+
+	RegisterFormat("hostname", func(s string) error {
+		if len(s) == 0 || len(s) > 253 || !hostnamePattern.MatchString(s) {
+			return fmt.Errorf("not a valid hostname")
+		}
+		return nil
+	})
+
+	RegisterFormat("credit-card", func(s string) error {
+		if !luhnValid(s) {
+			return fmt.Errorf("not a valid credit card number")
+		}
+		return nil
+	})
+
+	RegisterFormat("e164", func(s string) error {
+		if !e164Pattern.MatchString(s) {
+			return fmt.Errorf("not a valid E.164 phone number")
+		}
+		return nil
+	})
+
+	RegisterFormat("json", func(s string) error {
+		if !json.Valid([]byte(s)) {
+			return fmt.Errorf("not valid JSON")
+		}
+		return nil
+	})
+
+	RegisterFormat("uri", func(s string) error {
+		checker, _ := LookupFormat("url")
+		return checker(s)
+	})
+
+	RegisterFormat("duration", func(s string) error {
+		if _, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("not a valid duration")
+		}
+		return nil
+	})
+
+	RegisterFormatPredicate("regex", func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	})
+}
+
+// luhnValid reports whether s is a numeric string satisfying the Luhn checksum.
+func luhnValid(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	alt := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if alt {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		alt = !alt
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,85 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUUID(t *testing.T) {
+	schema := String().Format("uuid")
+
+	assert.NoError(t, schema.Validate("123e4567-e89b-12d3-a456-426614174000"))
+	assert.Error(t, schema.Validate("not-a-uuid"))
+}
+
+func TestFormatDateTime(t *testing.T) {
+	schema := String().Format("date-time")
+
+	assert.NoError(t, schema.Validate("2024-01-01T12:00:00Z"))
+	assert.Error(t, schema.Validate("2024-01-01"))
+}
+
+func TestFormatIPv4AndIPv6(t *testing.T) {
+	assert.NoError(t, String().Format("ipv4").Validate("192.168.1.1"))
+	assert.Error(t, String().Format("ipv4").Validate("::1"))
+	assert.NoError(t, String().Format("ipv6").Validate("::1"))
+}
+
+func TestFormatCreditCard(t *testing.T) {
+	schema := String().Format("credit-card")
+
+	assert.NoError(t, schema.Validate("4111111111111111"))
+	assert.Error(t, schema.Validate("4111111111111112"))
+}
+
+func TestFormatE164(t *testing.T) {
+	schema := String().Format("e164")
+
+	assert.NoError(t, schema.Validate("+14155552671"))
+	assert.Error(t, schema.Validate("14155552671"))
+}
+
+func TestFormatURIAliasesURL(t *testing.T) {
+	schema := String().Format("uri")
+
+	assert.NoError(t, schema.Validate("https://example.com"))
+	assert.Error(t, schema.Validate("not a uri"))
+}
+
+func TestFormatDuration(t *testing.T) {
+	schema := String().Format("duration")
+
+	assert.NoError(t, schema.Validate("1h30m"))
+	assert.Error(t, schema.Validate("not-a-duration"))
+}
+
+func TestFormatRegex(t *testing.T) {
+	schema := String().Format("regex")
+
+	assert.NoError(t, schema.Validate(`^[a-z]+$`))
+	assert.Error(t, schema.Validate(`(unterminated`))
+}
+
+func TestRegisterFormatPredicate(t *testing.T) {
+	RegisterFormatPredicate("even-length", func(s string) bool {
+		return len(s)%2 == 0
+	})
+
+	schema := String().Format("even-length")
+	assert.NoError(t, schema.Validate("1234"))
+	assert.Error(t, schema.Validate("123"))
+}
+
+func TestRegisterCustomFormat(t *testing.T) {
+	RegisterFormat("even-digits", func(s string) error {
+		if len(s)%2 != 0 {
+			return assert.AnError
+		}
+		return nil
+	})
+
+	schema := String().Format("even-digits")
+	assert.NoError(t, schema.Validate("1234"))
+	assert.Error(t, schema.Validate("123"))
+}
@@ -5,23 +5,54 @@ package isonantic
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 // Version is the current version of the isonantic-go package
 const Version = "1.0.0"
 
-// ValidationError represents a validation error with field path and message
+// ValidationError represents a validation error with field path and message.
+// Path holds the structured, JSON-pointer-style location of the error (e.g.
+// []string{"users", "1", "email"}), while Field retains the legacy flattened
+// string form for backward compatibility. Code is a stable, machine-readable
+// identifier (e.g. "string.min_length") and Params carries the constraint
+// values used to build Message. Message is an ErrorMessage rather than a
+// plain string so a caller can get at its Code/Params without re-parsing
+// rendered text (use NewMessage to build one by hand); Code/Params are also
+// duplicated directly onto ValidationError itself since most callers only
+// need the machine-readable form, not the message it was rendered into.
+// InstanceLocation and KeywordLocation mirror the same information in the
+// JSON-pointer-ish format used by JSON Schema's standardized error output,
+// for tools that expect that shape; AbsoluteKeywordLocation is
+// KeywordLocation with no base schema to resolve against, so it is
+// currently identical to it.
 type ValidationError struct {
-	Field   string
-	Message string
-	Value   interface{}
+	Field                   string
+	Path                    []string
+	Code                    string
+	Message                 ErrorMessage
+	Value                   interface{}
+	Params                  map[string]interface{}
+	InstanceLocation        string
+	KeywordLocation         string
+	AbsoluteKeywordLocation string
 }
 
 func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// Is implements errors.Is support: two ValidationErrors match if they share
+// a non-empty Code.
+func (e ValidationError) Is(target error) bool {
+	t, ok := target.(ValidationError)
+	if !ok || t.Code == "" || e.Code == "" {
+		return false
+	}
+	return t.Code == e.Code
+}
+
 // ValidationErrors is a collection of validation errors
 type ValidationErrors struct {
 	Errors []ValidationError
@@ -40,6 +71,16 @@ func (e ValidationErrors) HasErrors() bool {
 	return len(e.Errors) > 0
 }
 
+// Unwrap exposes the individual ValidationErrors so errors.Is/errors.As can
+// match a whole aggregated result against a sentinel or a single field error.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
 // Schema is the base interface for all schemas
 type Schema interface {
 	Validate(value interface{}) error
@@ -102,12 +143,12 @@ func (s *BaseSchema) runRefinements(value interface{}) error {
 // StringSchema validates string values
 type StringSchema struct {
 	BaseSchema
-	minLen      *int
-	maxLen      *int
-	exactLen    *int
-	pattern     *regexp.Regexp
-	isEmail     bool
-	isURL       bool
+	minLen   *int
+	maxLen   *int
+	exactLen *int
+	pattern  *regexp.Regexp
+	format   string
+	coerce   bool
 }
 
 // String creates a new string schema
@@ -133,15 +174,27 @@ func (s *StringSchema) Length(n int) *StringSchema {
 	return s
 }
 
+// Format validates the string against a named format registered via
+// RegisterFormat (e.g. "uuid", "date-time", "ipv4").
+func (s *StringSchema) Format(name string) *StringSchema {
+	s.format = name
+	return s
+}
+
 // Email validates email format
 func (s *StringSchema) Email() *StringSchema {
-	s.isEmail = true
-	return s
+	return s.Format("email")
 }
 
 // URL validates URL format
 func (s *StringSchema) URL() *StringSchema {
-	s.isURL = true
+	return s.Format("url")
+}
+
+// Coerce converts compatible non-string inputs (numbers, booleans) to their
+// string representation before constraint checks run.
+func (s *StringSchema) Coerce() *StringSchema {
+	s.coerce = true
 	return s
 }
 
@@ -208,17 +261,11 @@ func (s *StringSchema) Validate(value interface{}) error {
 		return fmt.Errorf("string must be exactly %d characters", *s.exactLen)
 	}
 
-	if s.isEmail {
-		emailPattern := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-		if !emailPattern.MatchString(str) {
-			return fmt.Errorf("invalid email format")
-		}
-	}
-
-	if s.isURL {
-		urlPattern := regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$`)
-		if !urlPattern.MatchString(str) {
-			return fmt.Errorf("invalid URL format")
+	if s.format != "" {
+		if checker, ok := LookupFormat(s.format); ok {
+			if err := checker(str); err != nil {
+				return fmt.Errorf("invalid %s format: %s", formatDisplayName(s.format), err)
+			}
 		}
 	}
 
@@ -232,11 +279,12 @@ func (s *StringSchema) Validate(value interface{}) error {
 // NumberSchema validates numeric values
 type NumberSchema struct {
 	BaseSchema
-	minVal      *float64
-	maxVal      *float64
-	isInt       bool
-	isPositive  bool
-	isNegative  bool
+	minVal     *float64
+	maxVal     *float64
+	isInt      bool
+	isPositive bool
+	isNegative bool
+	coerce     bool
 }
 
 // Number creates a new number schema
@@ -279,6 +327,16 @@ func (s *NumberSchema) Negative() *NumberSchema {
 	return s
 }
 
+// Coerce converts compatible inputs (numeric strings, json.Number, other
+// numeric kinds) to a float64/int64 before constraint checks run. This is
+// needed because encoding/json decodes all JSON numbers as float64 into
+// map[string]interface{}, so a plain Int() would otherwise reject numbers
+// that arrived as strings or json.Number.
+func (s *NumberSchema) Coerce() *NumberSchema {
+	s.coerce = true
+	return s
+}
+
 // Optional marks as optional
 func (s *NumberSchema) Optional() *NumberSchema {
 	s.setOptional()
@@ -368,6 +426,7 @@ func (s *NumberSchema) Validate(value interface{}) error {
 // BooleanSchema validates boolean values
 type BooleanSchema struct {
 	BaseSchema
+	coerce bool
 }
 
 // Boolean creates a new boolean schema
@@ -398,6 +457,13 @@ func (s *BooleanSchema) Describe(desc string) *BooleanSchema {
 	return s
 }
 
+// Coerce converts compatible inputs ("true"/"false", "1"/"0", "yes"/"no",
+// numeric 1/0) to a bool before constraint checks run.
+func (s *BooleanSchema) Coerce() *BooleanSchema {
+	s.coerce = true
+	return s
+}
+
 // Validate validates a boolean value
 func (s *BooleanSchema) Validate(value interface{}) error {
 	if value == nil {
@@ -514,7 +580,9 @@ func (s *RefSchema) Validate(value interface{}) error {
 // ObjectSchema validates object structures
 type ObjectSchema struct {
 	BaseSchema
-	fields map[string]Schema
+	fields           map[string]Schema
+	settings         SchemaValidationSettings
+	superRefinements []func(obj map[string]interface{}, ctx *RefineContext) error
 }
 
 // Object creates a new object schema
@@ -522,6 +590,12 @@ func Object(fields map[string]Schema) *ObjectSchema {
 	return &ObjectSchema{fields: fields}
 }
 
+// WithSettings configures the error-aggregation mode used by Validate.
+func (s *ObjectSchema) WithSettings(settings SchemaValidationSettings) *ObjectSchema {
+	s.settings = settings
+	return s
+}
+
 // Optional marks as optional
 func (s *ObjectSchema) Optional() *ObjectSchema {
 	s.setOptional()
@@ -595,12 +669,19 @@ func (s *ObjectSchema) Validate(value interface{}) error {
 				continue
 			}
 		}
+		if coercer, ok := schema.(Coercer); ok {
+			coerced, err := coercer.CoerceValue(fieldValue)
+			if err == nil {
+				fieldValue = coerced
+				obj[name] = fieldValue
+			}
+		}
 		if err := schema.Validate(fieldValue); err != nil {
-			errs.Errors = append(errs.Errors, ValidationError{
-				Field:   name,
-				Message: err.Error(),
-				Value:   fieldValue,
-			})
+			code, params := classifyError(err)
+			errs.Errors = append(errs.Errors, newValidationError(name, []string{name}, code, err.Error(), fieldValue, params))
+			if !errs.keepGoing(s.settings) {
+				break
+			}
 		}
 	}
 
@@ -608,6 +689,16 @@ func (s *ObjectSchema) Validate(value interface{}) error {
 		return errs
 	}
 
+	rc := &RefineContext{errs: &errs}
+	for _, fn := range s.superRefinements {
+		if err := fn(obj, rc); err != nil {
+			errs.Errors = append(errs.Errors, newValidationError("", nil, "", err.Error(), nil, nil))
+		}
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
 	return s.runRefinements(value)
 }
 
@@ -617,6 +708,7 @@ type ArraySchema struct {
 	itemSchema Schema
 	minLen     *int
 	maxLen     *int
+	settings   SchemaValidationSettings
 }
 
 // Array creates a new array schema
@@ -624,6 +716,12 @@ func Array(itemSchema Schema) *ArraySchema {
 	return &ArraySchema{itemSchema: itemSchema}
 }
 
+// WithSettings configures the error-aggregation mode used by Validate.
+func (s *ArraySchema) WithSettings(settings SchemaValidationSettings) *ArraySchema {
+	s.settings = settings
+	return s
+}
+
 // Min sets minimum length
 func (s *ArraySchema) Min(n int) *ArraySchema {
 	s.minLen = &n
@@ -672,12 +770,20 @@ func (s *ArraySchema) Validate(value interface{}) error {
 
 	var errs ValidationErrors
 	for i, item := range arr {
+		if coercer, ok := s.itemSchema.(Coercer); ok {
+			if coerced, err := coercer.CoerceValue(item); err == nil {
+				item = coerced
+				arr[i] = item
+			}
+		}
 		if err := s.itemSchema.Validate(item); err != nil {
-			errs.Errors = append(errs.Errors, ValidationError{
-				Field:   fmt.Sprintf("[%d]", i),
-				Message: err.Error(),
-				Value:   item,
-			})
+			code, params := classifyError(err)
+			field := fmt.Sprintf("[%d]", i)
+			path := []string{strconv.Itoa(i)}
+			errs.Errors = append(errs.Errors, newValidationError(field, path, code, err.Error(), item, params))
+			if !errs.keepGoing(s.settings) {
+				break
+			}
 		}
 	}
 
@@ -691,9 +797,46 @@ func (s *ArraySchema) Validate(value interface{}) error {
 // TableSchema validates ISON table blocks
 type TableSchema struct {
 	BaseSchema
-	name       string
-	fields     map[string]Schema
-	rowSchema  *ObjectSchema
+	name        string
+	fields      map[string]Schema
+	rowSchema   *ObjectSchema
+	settings    SchemaValidationSettings
+	primaryKey  []string
+	uniqueKeys  [][]string
+	foreignKeys []tableForeignKey
+}
+
+// tableForeignKey records a Table.ForeignKey constraint for enforcement by
+// Document.Parse, which has access to the referenced table's rows.
+type tableForeignKey struct {
+	field      string
+	otherTable string
+	otherField string
+}
+
+// PrimaryKey declares that fields must be unique and non-null across all rows.
+func (s *TableSchema) PrimaryKey(fields ...string) *TableSchema {
+	s.primaryKey = fields
+	return s
+}
+
+// Unique declares that the combination of fields must be unique across all rows.
+func (s *TableSchema) Unique(fields ...string) *TableSchema {
+	s.uniqueKeys = append(s.uniqueKeys, fields)
+	return s
+}
+
+// ForeignKey declares that field must match otherField in otherTable for
+// every row; enforced by DocumentSchema.Parse once both tables are known.
+func (s *TableSchema) ForeignKey(field, otherTable, otherField string) *TableSchema {
+	s.foreignKeys = append(s.foreignKeys, tableForeignKey{field: field, otherTable: otherTable, otherField: otherField})
+	return s
+}
+
+// WithSettings configures the error-aggregation mode used by Validate.
+func (s *TableSchema) WithSettings(settings SchemaValidationSettings) *TableSchema {
+	s.settings = settings
+	return s
 }
 
 // Table creates a new table schema
@@ -751,30 +894,31 @@ func (s *TableSchema) Validate(value interface{}) error {
 func (s *TableSchema) validateRows(rows []interface{}) error {
 	var errs ValidationErrors
 	for i, row := range rows {
+		rowIdx := strconv.Itoa(i)
 		rowMap, ok := row.(map[string]interface{})
 		if !ok {
-			errs.Errors = append(errs.Errors, ValidationError{
-				Field:   fmt.Sprintf("row[%d]", i),
-				Message: "expected row object",
-				Value:   row,
-			})
+			errs.Errors = append(errs.Errors, newValidationError(fmt.Sprintf("row[%d]", i), []string{rowIdx}, "table.invalid_row", "expected row object", row, nil))
+			if !errs.keepGoing(s.settings) {
+				break
+			}
 			continue
 		}
 		if err := s.rowSchema.Validate(rowMap); err != nil {
 			if ve, ok := err.(ValidationErrors); ok {
 				for _, e := range ve.Errors {
-					errs.Errors = append(errs.Errors, ValidationError{
-						Field:   fmt.Sprintf("row[%d].%s", i, e.Field),
-						Message: e.Message,
-						Value:   e.Value,
-					})
+					field := fmt.Sprintf("row[%d].%s", i, e.Field)
+					path := append([]string{rowIdx}, e.Path...)
+					errs.Errors = append(errs.Errors, newValidationError(field, path, e.Code, e.Message.String(), e.Value, e.Params))
+					if !errs.keepGoing(s.settings) {
+						return errs
+					}
 				}
 			} else {
-				errs.Errors = append(errs.Errors, ValidationError{
-					Field:   fmt.Sprintf("row[%d]", i),
-					Message: err.Error(),
-					Value:   row,
-				})
+				code, params := classifyError(err)
+				errs.Errors = append(errs.Errors, newValidationError(fmt.Sprintf("row[%d]", i), []string{rowIdx}, code, err.Error(), row, params))
+				if !errs.keepGoing(s.settings) {
+					break
+				}
 			}
 		}
 	}
@@ -783,12 +927,64 @@ func (s *TableSchema) validateRows(rows []interface{}) error {
 		return errs
 	}
 
+	if err := s.validateKeyConstraints(rows); err != nil {
+		return err
+	}
+
 	return s.runRefinements(rows)
 }
 
+// validateKeyConstraints enforces PrimaryKey and Unique across all rows of
+// the table. ForeignKey constraints are enforced separately by
+// DocumentSchema.Parse, which has visibility into the referenced table.
+func (s *TableSchema) validateKeyConstraints(rows []interface{}) error {
+	var errs ValidationErrors
+
+	checkUnique := func(fields []string, code string) {
+		seen := make(map[string]int)
+		for i, row := range rows {
+			rowMap, ok := row.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key := ""
+			for _, f := range fields {
+				v, present := rowMap[f]
+				if !present || v == nil {
+					field := fmt.Sprintf("row[%d].%s", i, f)
+					path := []string{strconv.Itoa(i), f}
+					errs.Errors = append(errs.Errors, newValidationError(field, path, code, fmt.Sprintf("key field %q must not be null", f), nil, nil))
+					return
+				}
+				key += fmt.Sprintf("%v|", v)
+			}
+			if firstIdx, dup := seen[key]; dup {
+				field := fmt.Sprintf("row[%d]", i)
+				path := []string{strconv.Itoa(i)}
+				errs.Errors = append(errs.Errors, newValidationError(field, path, code, fmt.Sprintf("duplicate key %v also present at row[%d]", fields, firstIdx), nil, nil))
+			} else {
+				seen[key] = i
+			}
+		}
+	}
+
+	if len(s.primaryKey) > 0 {
+		checkUnique(s.primaryKey, "table.primary_key_violation")
+	}
+	for _, fields := range s.uniqueKeys {
+		checkUnique(fields, "table.unique_violation")
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+	return nil
+}
+
 // DocumentSchema validates complete ISON documents
 type DocumentSchema struct {
-	blocks map[string]Schema
+	blocks   map[string]Schema
+	settings SchemaValidationSettings
 }
 
 // Document creates a new document schema
@@ -796,27 +992,76 @@ func Document(blocks map[string]Schema) *DocumentSchema {
 	return &DocumentSchema{blocks: blocks}
 }
 
+// WithSettings configures the error-aggregation mode used by Parse.
+func (s *DocumentSchema) WithSettings(settings SchemaValidationSettings) *DocumentSchema {
+	s.settings = settings
+	return s
+}
+
 // Parse validates a document and returns the validated data
 func (s *DocumentSchema) Parse(value map[string]interface{}) (map[string]interface{}, error) {
 	var errs ValidationErrors
 
 	for name, schema := range s.blocks {
 		blockValue := value[name]
+		if coercer, ok := schema.(Coercer); ok {
+			if coerced, err := coercer.CoerceValue(blockValue); err == nil {
+				blockValue = coerced
+				value[name] = blockValue
+			}
+		}
 		if err := schema.Validate(blockValue); err != nil {
 			if ve, ok := err.(ValidationErrors); ok {
 				for _, e := range ve.Errors {
-					errs.Errors = append(errs.Errors, ValidationError{
-						Field:   fmt.Sprintf("%s.%s", name, e.Field),
-						Message: e.Message,
-						Value:   e.Value,
-					})
+					field := fmt.Sprintf("%s.%s", name, e.Field)
+					path := append([]string{name}, e.Path...)
+					errs.Errors = append(errs.Errors, newValidationError(field, path, e.Code, e.Message.String(), e.Value, e.Params))
+					if !errs.keepGoing(s.settings) {
+						return nil, errs
+					}
 				}
 			} else {
-				errs.Errors = append(errs.Errors, ValidationError{
-					Field:   name,
-					Message: err.Error(),
-					Value:   blockValue,
-				})
+				code, params := classifyError(err)
+				errs.Errors = append(errs.Errors, newValidationError(name, []string{name}, code, err.Error(), blockValue, params))
+				if !errs.keepGoing(s.settings) {
+					return nil, errs
+				}
+			}
+		}
+	}
+
+	for name, schema := range s.blocks {
+		tableSchema, ok := schema.(*TableSchema)
+		if !ok || len(tableSchema.foreignKeys) == 0 {
+			continue
+		}
+		rows, _ := value[name].([]interface{})
+		for _, fk := range tableSchema.foreignKeys {
+			otherRows, _ := value[fk.otherTable].([]interface{})
+			known := make(map[string]bool, len(otherRows))
+			for _, r := range otherRows {
+				if rowMap, ok := r.(map[string]interface{}); ok {
+					known[fmt.Sprintf("%v", rowMap[fk.otherField])] = true
+				}
+			}
+			for i, r := range rows {
+				rowMap, ok := r.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				v, present := rowMap[fk.field]
+				if !present || v == nil {
+					continue
+				}
+				if !known[fmt.Sprintf("%v", v)] {
+					field := fmt.Sprintf("%s.row[%d].%s", name, i, fk.field)
+					path := []string{name, strconv.Itoa(i), fk.field}
+					msg := fmt.Sprintf("references missing %s.%s = %v", fk.otherTable, fk.otherField, v)
+					errs.Errors = append(errs.Errors, newValidationError(field, path, "table.foreign_key_violation", msg, v, nil))
+					if !errs.keepGoing(s.settings) {
+						return nil, errs
+					}
+				}
 			}
 		}
 	}
@@ -846,29 +1091,45 @@ func (s *DocumentSchema) SafeParse(value map[string]interface{}) SafeParseResult
 
 // I provides a namespace for schema creation (like Zod's z)
 var I = struct {
-	String    func() *StringSchema
-	Number    func() *NumberSchema
-	Int       func() *NumberSchema
-	Float     func() *NumberSchema
-	Boolean   func() *BooleanSchema
-	Bool      func() *BooleanSchema
-	Null      func() *NullSchema
-	Ref       func() *RefSchema
-	Reference func() *RefSchema
-	Object    func(map[string]Schema) *ObjectSchema
-	Array     func(Schema) *ArraySchema
-	Table     func(string, map[string]Schema) *TableSchema
+	String             func() *StringSchema
+	Number             func() *NumberSchema
+	Int                func() *NumberSchema
+	Float              func() *NumberSchema
+	Boolean            func() *BooleanSchema
+	Bool               func() *BooleanSchema
+	Null               func() *NullSchema
+	Ref                func() *RefSchema
+	Reference          func() *RefSchema
+	Object             func(map[string]Schema) *ObjectSchema
+	Array              func(Schema) *ArraySchema
+	Table              func(string, map[string]Schema) *TableSchema
+	Struct             func(interface{}) Schema
+	Union              func(...Schema) *UnionSchema
+	DiscriminatedUnion func(string, map[string]Schema) *DiscriminatedUnionSchema
+	Tuple              func(...Schema) *TupleSchema
+	Record             func(Schema, Schema) *RecordSchema
+	Lazy               func(string) *LazyRefSchema
+	Recursive          func(func() Schema) *RecursiveSchema
+	Enum               func(...interface{}) *EnumSchema
 }{
-	String:    String,
-	Number:    Number,
-	Int:       Int,
-	Float:     Float,
-	Boolean:   Boolean,
-	Bool:      Bool,
-	Null:      Null,
-	Ref:       Ref,
-	Reference: Reference,
-	Object:    Object,
-	Array:     Array,
-	Table:     Table,
+	String:             String,
+	Number:             Number,
+	Int:                Int,
+	Float:              Float,
+	Boolean:            Boolean,
+	Bool:               Bool,
+	Null:               Null,
+	Ref:                Ref,
+	Reference:          Reference,
+	Object:             Object,
+	Array:              Array,
+	Table:              Table,
+	Struct:             Struct,
+	Union:              Union,
+	DiscriminatedUnion: DiscriminatedUnion,
+	Tuple:              Tuple,
+	Record:             Record,
+	Lazy:               Lazy,
+	Recursive:          Recursive,
+	Enum:               Enum,
 }
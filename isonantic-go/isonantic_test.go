@@ -687,7 +687,7 @@ func TestINamespaceUsage(t *testing.T) {
 func TestValidationErrorString(t *testing.T) {
 	err := ValidationError{
 		Field:   "email",
-		Message: "invalid email format",
+		Message: NewMessage("invalid email format", "", nil),
 		Value:   "not-an-email",
 	}
 
@@ -697,8 +697,8 @@ func TestValidationErrorString(t *testing.T) {
 func TestValidationErrorsString(t *testing.T) {
 	errs := ValidationErrors{
 		Errors: []ValidationError{
-			{Field: "email", Message: "invalid email"},
-			{Field: "name", Message: "required"},
+			{Field: "email", Message: NewMessage("invalid email", "", nil)},
+			{Field: "name", Message: NewMessage("required", "", nil)},
 		},
 	}
 
@@ -711,7 +711,7 @@ func TestValidationErrorsHasErrors(t *testing.T) {
 	assert.False(t, empty.HasErrors())
 
 	withErrors := ValidationErrors{
-		Errors: []ValidationError{{Field: "test", Message: "error"}},
+		Errors: []ValidationError{{Field: "test", Message: NewMessage("error", "", nil)}},
 	}
 	assert.True(t, withErrors.HasErrors())
 }
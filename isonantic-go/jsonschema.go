@@ -0,0 +1,311 @@
+package isonantic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ToJSONSchema converts an isonantic Schema into a JSON Schema (Draft 2020-12) document.
+func ToJSONSchema(s Schema) ([]byte, error) {
+	doc, err := schemaToJSONSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func schemaToJSONSchema(s Schema) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+
+	switch schema := s.(type) {
+	case *StringSchema:
+		doc["type"] = "string"
+		if schema.minLen != nil {
+			doc["minLength"] = *schema.minLen
+		}
+		if schema.maxLen != nil {
+			doc["maxLength"] = *schema.maxLen
+		}
+		if schema.exactLen != nil {
+			doc["minLength"] = *schema.exactLen
+			doc["maxLength"] = *schema.exactLen
+		}
+		if schema.pattern != nil {
+			doc["pattern"] = schema.pattern.String()
+		}
+		switch schema.format {
+		case "email":
+			doc["format"] = "email"
+		case "url":
+			doc["format"] = "uri"
+		case "":
+			// no format
+		default:
+			doc["format"] = schema.format
+		}
+
+	case *NumberSchema:
+		if schema.isInt {
+			doc["type"] = "integer"
+		} else {
+			doc["type"] = "number"
+		}
+		if schema.minVal != nil {
+			doc["minimum"] = *schema.minVal
+		}
+		if schema.maxVal != nil {
+			doc["maximum"] = *schema.maxVal
+		}
+		if schema.isPositive {
+			doc["exclusiveMinimum"] = 0
+		}
+		if schema.isNegative {
+			doc["exclusiveMaximum"] = 0
+		}
+
+	case *BooleanSchema:
+		doc["type"] = "boolean"
+
+	case *NullSchema:
+		doc["type"] = "null"
+
+	case *RefSchema:
+		ref := make(map[string]interface{})
+		if schema.namespace != nil {
+			ref["namespace"] = *schema.namespace
+		}
+		if schema.relationship != nil {
+			ref["relationship"] = *schema.relationship
+		}
+		doc["x-isonantic-ref"] = ref
+
+	case *ObjectSchema:
+		doc["type"] = "object"
+		props := make(map[string]interface{})
+		var required []string
+		for name, fieldSchema := range schema.fields {
+			fieldDoc, err := schemaToJSONSchema(fieldSchema)
+			if err != nil {
+				return nil, err
+			}
+			props[name] = fieldDoc
+			if !fieldSchema.IsOptional() {
+				required = append(required, name)
+			}
+		}
+		doc["properties"] = props
+		if len(required) > 0 {
+			doc["required"] = required
+		}
+		doc["additionalProperties"] = false
+
+	case *ArraySchema:
+		doc["type"] = "array"
+		itemDoc, err := schemaToJSONSchema(schema.itemSchema)
+		if err != nil {
+			return nil, err
+		}
+		doc["items"] = itemDoc
+		if schema.minLen != nil {
+			doc["minItems"] = *schema.minLen
+		}
+		if schema.maxLen != nil {
+			doc["maxItems"] = *schema.maxLen
+		}
+
+	case *TableSchema:
+		doc["type"] = "array"
+		rowDoc, err := schemaToJSONSchema(schema.rowSchema)
+		if err != nil {
+			return nil, err
+		}
+		doc["items"] = rowDoc
+		doc["x-isonantic-table"] = map[string]interface{}{"name": schema.name}
+
+	default:
+		return nil, fmt.Errorf("isonantic: unsupported schema type %T for JSON Schema export", s)
+	}
+
+	if desc := s.GetDescription(); desc != "" {
+		doc["description"] = desc
+	}
+	if def, hasDefault := s.GetDefault(); hasDefault {
+		doc["default"] = def
+	}
+
+	return doc, nil
+}
+
+// FromJSONSchema parses a JSON Schema (Draft 2020-12) document into an isonantic Schema.
+func FromJSONSchema(data []byte) (Schema, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("isonantic: invalid JSON Schema: %w", err)
+	}
+	return jsonSchemaToSchema(doc)
+}
+
+func jsonSchemaToSchema(doc map[string]interface{}) (Schema, error) {
+	if refMeta, ok := doc["x-isonantic-ref"].(map[string]interface{}); ok {
+		ref := Ref()
+		if ns, ok := refMeta["namespace"].(string); ok && ns != "" {
+			ref.Namespace(ns)
+		}
+		if rel, ok := refMeta["relationship"].(string); ok && rel != "" {
+			ref.Relationship(rel)
+		}
+		return applyCommon(ref, doc), nil
+	}
+
+	typeName, _ := doc["type"].(string)
+
+	switch typeName {
+	case "string":
+		s := String()
+		if v, ok := doc["minLength"].(float64); ok {
+			s.Min(int(v))
+		}
+		if v, ok := doc["maxLength"].(float64); ok {
+			s.Max(int(v))
+		}
+		if format, ok := doc["format"].(string); ok {
+			switch format {
+			case "email":
+				s.Email()
+			case "uri", "url":
+				s.URL()
+			default:
+				s.Format(format)
+			}
+		}
+		return applyCommon(s, doc), nil
+
+	case "integer", "number":
+		n := Number()
+		if typeName == "integer" {
+			n = Int()
+		}
+		if v, ok := doc["minimum"].(float64); ok {
+			n.Min(v)
+		}
+		if v, ok := doc["maximum"].(float64); ok {
+			n.Max(v)
+		}
+		if _, ok := doc["exclusiveMinimum"]; ok {
+			n.Positive()
+		}
+		if _, ok := doc["exclusiveMaximum"]; ok {
+			n.Negative()
+		}
+		return applyCommon(n, doc), nil
+
+	case "boolean":
+		return applyCommon(Boolean(), doc), nil
+
+	case "null":
+		return Null(), nil
+
+	case "object":
+		fields := make(map[string]Schema)
+		if props, ok := doc["properties"].(map[string]interface{}); ok {
+			required := make(map[string]bool)
+			if reqList, ok := doc["required"].([]interface{}); ok {
+				for _, r := range reqList {
+					if name, ok := r.(string); ok {
+						required[name] = true
+					}
+				}
+			}
+			for name, propDoc := range props {
+				propMap, ok := propDoc.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				fieldSchema, err := jsonSchemaToSchema(propMap)
+				if err != nil {
+					return nil, err
+				}
+				if !required[name] {
+					fieldSchema = markOptional(fieldSchema)
+				}
+				fields[name] = fieldSchema
+			}
+		}
+		return applyCommon(Object(fields), doc), nil
+
+	case "array":
+		if tableMeta, ok := doc["x-isonantic-table"].(map[string]interface{}); ok {
+			name, _ := tableMeta["name"].(string)
+			itemDoc, _ := doc["items"].(map[string]interface{})
+			rowSchema, err := jsonSchemaToSchema(itemDoc)
+			if err != nil {
+				return nil, err
+			}
+			objSchema, ok := rowSchema.(*ObjectSchema)
+			if !ok {
+				return nil, fmt.Errorf("isonantic: x-isonantic-table items must describe an object")
+			}
+			return Table(name, objSchema.fields), nil
+		}
+		itemDoc, _ := doc["items"].(map[string]interface{})
+		itemSchema, err := jsonSchemaToSchema(itemDoc)
+		if err != nil {
+			return nil, err
+		}
+		arr := Array(itemSchema)
+		if v, ok := doc["minItems"].(float64); ok {
+			arr.Min(int(v))
+		}
+		if v, ok := doc["maxItems"].(float64); ok {
+			arr.Max(int(v))
+		}
+		return applyCommon(arr, doc), nil
+
+	default:
+		return nil, fmt.Errorf("isonantic: unsupported JSON Schema type %q", typeName)
+	}
+}
+
+// markOptional wraps a schema's optional flag via its builder Optional() method where available.
+func markOptional(s Schema) Schema {
+	switch sc := s.(type) {
+	case *StringSchema:
+		return sc.Optional()
+	case *NumberSchema:
+		return sc.Optional()
+	case *BooleanSchema:
+		return sc.Optional()
+	case *ObjectSchema:
+		return sc.Optional()
+	case *ArraySchema:
+		return sc.Optional()
+	case *RefSchema:
+		return sc.Optional()
+	case *TableSchema:
+		return sc.Optional()
+	default:
+		return s
+	}
+}
+
+func applyCommon(s Schema, doc map[string]interface{}) Schema {
+	if desc, ok := doc["description"].(string); ok {
+		switch sc := s.(type) {
+		case *StringSchema:
+			sc.Describe(desc)
+		case *NumberSchema:
+			sc.Describe(desc)
+		case *BooleanSchema:
+			sc.Describe(desc)
+		case *ObjectSchema:
+			sc.Describe(desc)
+		case *ArraySchema:
+			sc.Describe(desc)
+		case *RefSchema:
+			sc.Describe(desc)
+		case *TableSchema:
+			sc.Describe(desc)
+		}
+	}
+	return s
+}
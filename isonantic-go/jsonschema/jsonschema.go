@@ -0,0 +1,21 @@
+// Package jsonschema is the public import path for isonantic's JSON Schema
+// (Draft 2020-12) bridge. The conversion itself (ToJSONSchema/FromJSONSchema
+// in the parent isonantic package) needs direct access to each Schema
+// implementation's unexported fields, so it stays there; this package
+// re-exports it under the path users reach for when they want "isonantic
+// JSON Schema support" without pulling in validation internals.
+package jsonschema
+
+import "github.com/maheshvaikri-code/ison/isonantic-go"
+
+// ToJSONSchema converts an isonantic Schema into a JSON Schema (Draft
+// 2020-12) document. See isonantic.ToJSONSchema for the supported keywords.
+func ToJSONSchema(s isonantic.Schema) ([]byte, error) {
+	return isonantic.ToJSONSchema(s)
+}
+
+// FromJSONSchema parses a JSON Schema (Draft 2020-12) document into an
+// isonantic Schema. See isonantic.FromJSONSchema for the supported subset.
+func FromJSONSchema(data []byte) (isonantic.Schema, error) {
+	return isonantic.FromJSONSchema(data)
+}
@@ -0,0 +1,22 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/maheshvaikri-code/ison/isonantic-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSONSchemaForwardsToParentPackage(t *testing.T) {
+	data, err := ToJSONSchema(isonantic.String().Min(1))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type": "string"`)
+}
+
+func TestFromJSONSchemaForwardsToParentPackage(t *testing.T) {
+	s, err := FromJSONSchema([]byte(`{"type": "string", "minLength": 2}`))
+	require.NoError(t, err)
+	assert.Error(t, s.Validate("a"))
+	assert.NoError(t, s.Validate("ab"))
+}
@@ -0,0 +1,68 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSONSchemaString(t *testing.T) {
+	schema := String().Min(2).Max(10).Email()
+
+	data, err := ToJSONSchema(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type": "string"`)
+	assert.Contains(t, string(data), `"format": "email"`)
+}
+
+func TestToJSONSchemaObject(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"name": String(),
+		"age":  Int().Optional(),
+	})
+
+	data, err := ToJSONSchema(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type": "object"`)
+	assert.Contains(t, string(data), `"name"`)
+}
+
+func TestToJSONSchemaTable(t *testing.T) {
+	schema := Table("users", map[string]Schema{
+		"id": Int(),
+	})
+
+	data, err := ToJSONSchema(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"x-isonantic-table"`)
+}
+
+func TestJSONSchemaRoundtrip(t *testing.T) {
+	original := Object(map[string]Schema{
+		"email": String().Email(),
+		"age":   Int().Min(0).Optional(),
+	})
+
+	data, err := ToJSONSchema(original)
+	require.NoError(t, err)
+
+	recovered, err := FromJSONSchema(data)
+	require.NoError(t, err)
+
+	err = recovered.Validate(map[string]interface{}{"email": "a@b.com", "age": 5.0})
+	assert.NoError(t, err)
+
+	err = recovered.Validate(map[string]interface{}{"email": "not-an-email"})
+	assert.Error(t, err)
+}
+
+func TestFromJSONSchemaRef(t *testing.T) {
+	data := []byte(`{"x-isonantic-ref": {"namespace": "users"}}`)
+
+	schema, err := FromJSONSchema(data)
+	require.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{"_ref": "1", "_namespace": "users"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package isonantic
+
+import "sync"
+
+// Locale renders a ValidationError into human-readable text from its Code
+// and Params, letting downstream users produce error text in other
+// languages (or structured JSON) without string-parsing Message.
+type Locale interface {
+	Render(e ValidationError) string
+}
+
+// englishLocale is the default Locale, simply returning the error's
+// existing Message (already English, built by the validator that raised it).
+type englishLocale struct{}
+
+func (englishLocale) Render(e ValidationError) string {
+	return e.Message.String()
+}
+
+var (
+	localeMu     sync.RWMutex
+	locales             = map[string]Locale{"en": englishLocale{}}
+	activeLocale Locale = englishLocale{}
+)
+
+// RegisterLocale registers a named Locale, selectable later via SetLocale.
+func RegisterLocale(name string, l Locale) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	locales[name] = l
+}
+
+// SetLocale selects the active Locale by name. It reports false, leaving
+// the active Locale unchanged, if name hasn't been registered.
+func SetLocale(name string) bool {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	l, ok := locales[name]
+	if !ok {
+		return false
+	}
+	activeLocale = l
+	return true
+}
+
+// Localized renders e using the currently active Locale (English by default).
+func (e ValidationError) Localized() string {
+	localeMu.RLock()
+	l := activeLocale
+	localeMu.RUnlock()
+	return l.Render(e)
+}
@@ -0,0 +1,60 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrorHasInstanceAndKeywordLocation(t *testing.T) {
+	schema := Object(map[string]Schema{"email": String().Email()})
+
+	err := schema.Validate(map[string]interface{}{"email": "not-an-email"})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+
+	e := verrs.Errors[0]
+	assert.Equal(t, "/email", e.InstanceLocation)
+	assert.Equal(t, "#/string/invalid_email", e.KeywordLocation)
+	assert.Equal(t, e.KeywordLocation, e.AbsoluteKeywordLocation)
+}
+
+func TestValidationErrorTableRowInstanceLocation(t *testing.T) {
+	schema := Table("users", map[string]Schema{"email": String().Email()})
+
+	err := schema.Validate([]interface{}{
+		map[string]interface{}{"email": "not-an-email"},
+	})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Equal(t, "/0/email", verrs.Errors[0].InstanceLocation)
+}
+
+type frenchLocale struct{}
+
+func (frenchLocale) Render(e ValidationError) string {
+	if e.Code == "string.invalid_email" {
+		return "adresse e-mail invalide"
+	}
+	return e.Message.String()
+}
+
+func TestRegisterAndSetLocale(t *testing.T) {
+	RegisterLocale("fr", frenchLocale{})
+	require.True(t, SetLocale("fr"))
+	defer SetLocale("en")
+
+	schema := Object(map[string]Schema{"email": String().Email()})
+	err := schema.Validate(map[string]interface{}{"email": "nope"})
+	require.Error(t, err)
+	verrs := err.(ValidationErrors)
+
+	assert.Equal(t, "adresse e-mail invalide", verrs.Errors[0].Localized())
+}
+
+func TestSetLocaleUnknownNameFails(t *testing.T) {
+	assert.False(t, SetLocale("xx-unregistered"))
+}
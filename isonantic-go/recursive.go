@@ -0,0 +1,182 @@
+package isonantic
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Compiler resolves named schemas by id, letting schemas reference each
+// other (or themselves) before every id in the graph has been constructed.
+// This is what makes self-referential shapes possible — a Comment whose
+// replies field is an Array of more Comments can't be built with a plain
+// Object(map[string]Schema{...}) literal, since the field would need to
+// refer to the very map literal that contains it.
+type Compiler struct {
+	mu      sync.RWMutex
+	schemas map[string]Schema
+}
+
+// NewCompiler creates an empty Compiler.
+func NewCompiler() *Compiler {
+	return &Compiler{schemas: make(map[string]Schema)}
+}
+
+// Register stores s under id for later resolution by Compile or Lazy.
+func (c *Compiler) Register(id string, s Schema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schemas[id] = s
+}
+
+// Compile returns the schema registered under id.
+func (c *Compiler) Compile(id string) (Schema, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	s, ok := c.schemas[id]
+	if !ok {
+		return nil, fmt.Errorf("isonantic: no schema registered for id %q", id)
+	}
+	return s, nil
+}
+
+// Lazy returns a schema that looks up id in c on every Validate call rather
+// than at construction time, so a schema graph can reference an id that
+// hasn't been Register-ed yet (as happens with direct or mutual recursion).
+func (c *Compiler) Lazy(id string) *LazyRefSchema {
+	return &LazyRefSchema{compiler: c, id: id}
+}
+
+// LazyRefSchema defers resolution of a named schema to validation time.
+type LazyRefSchema struct {
+	BaseSchema
+	compiler *Compiler
+	id       string
+	inFlight sync.Map
+}
+
+// Optional marks as optional
+func (s *LazyRefSchema) Optional() *LazyRefSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *LazyRefSchema) Describe(desc string) *LazyRefSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate resolves s's id against its Compiler and validates value
+// against the result, detecting instance-side cycles (the same map, slice,
+// or pointer reappearing further down the graph it's already part of).
+func (s *LazyRefSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	if ptr, ok := pointerIdentity(value); ok {
+		if _, cyclic := s.inFlight.LoadOrStore(ptr, true); cyclic {
+			return fmt.Errorf("cyclic reference detected while validating %q", s.id)
+		}
+		defer s.inFlight.Delete(ptr)
+	}
+
+	resolved, err := s.compiler.Compile(s.id)
+	if err != nil {
+		return err
+	}
+	return resolved.Validate(value)
+}
+
+var defaultCompiler = NewCompiler()
+
+// Register stores s under id in the package's default Compiler, used by
+// the free Compile and Lazy functions.
+func Register(id string, s Schema) {
+	defaultCompiler.Register(id, s)
+}
+
+// Compile resolves id against the package's default Compiler.
+func Compile(id string) (Schema, error) {
+	return defaultCompiler.Compile(id)
+}
+
+// Lazy returns a schema that resolves id from the package's default
+// Compiler on each Validate call. Use a dedicated Compiler instead if your
+// application needs isolated registries (e.g. per-tenant schema sets).
+func Lazy(id string) *LazyRefSchema {
+	return defaultCompiler.Lazy(id)
+}
+
+// RecursiveSchema wraps a schema whose definition is only available once
+// construction has finished, e.g. an Object that contains itself.
+type RecursiveSchema struct {
+	BaseSchema
+	resolve  func() Schema
+	once     sync.Once
+	resolved Schema
+	inFlight sync.Map
+}
+
+// Recursive returns a schema that calls fn once, on first use, to produce
+// its real definition — so fn can close over a variable that isn't
+// assigned until after Recursive(...) returns, such as a Comment schema
+// whose replies field is Array(Recursive(func() Schema { return comment })).
+func Recursive(fn func() Schema) *RecursiveSchema {
+	return &RecursiveSchema{resolve: fn}
+}
+
+// Optional marks as optional
+func (s *RecursiveSchema) Optional() *RecursiveSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *RecursiveSchema) Describe(desc string) *RecursiveSchema {
+	s.setDescription(desc)
+	return s
+}
+
+func (s *RecursiveSchema) schema() Schema {
+	s.once.Do(func() {
+		s.resolved = s.resolve()
+	})
+	return s.resolved
+}
+
+// Validate resolves s's definition (once) and validates value against it,
+// detecting instance-side cycles the same way LazyRefSchema does.
+func (s *RecursiveSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	if ptr, ok := pointerIdentity(value); ok {
+		if _, cyclic := s.inFlight.LoadOrStore(ptr, true); cyclic {
+			return fmt.Errorf("cyclic reference detected")
+		}
+		defer s.inFlight.Delete(ptr)
+	}
+
+	return s.schema().Validate(value)
+}
+
+// pointerIdentity returns value's underlying pointer, for map/slice/pointer
+// values whose identity (not just contents) matters for cycle detection.
+func pointerIdentity(value interface{}) (uintptr, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		return v.Pointer(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,86 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerRegisterAndCompile(t *testing.T) {
+	c := NewCompiler()
+	c.Register("user", Object(map[string]Schema{"name": String()}))
+
+	resolved, err := c.Compile("user")
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]interface{}{"name": "Ada"}))
+
+	_, err = c.Compile("missing")
+	assert.Error(t, err)
+}
+
+func TestCompilerLazySupportsForwardReference(t *testing.T) {
+	c := NewCompiler()
+
+	// comment references itself via Lazy before "comment" is registered.
+	comment := Object(map[string]Schema{
+		"body":    String(),
+		"replies": Array(c.Lazy("comment")).Optional(),
+	})
+	c.Register("comment", comment)
+
+	value := map[string]interface{}{
+		"body": "top-level",
+		"replies": []interface{}{
+			map[string]interface{}{"body": "a reply"},
+		},
+	}
+	assert.NoError(t, comment.Validate(value))
+}
+
+func TestRecursiveSchemaSelfReference(t *testing.T) {
+	var commentSchema Schema
+	commentSchema = Object(map[string]Schema{
+		"body":    String(),
+		"replies": Array(Recursive(func() Schema { return commentSchema })).Optional(),
+	})
+
+	value := map[string]interface{}{
+		"body": "top-level",
+		"replies": []interface{}{
+			map[string]interface{}{
+				"body": "nested reply",
+				"replies": []interface{}{
+					map[string]interface{}{"body": "deeply nested"},
+				},
+			},
+		},
+	}
+	assert.NoError(t, commentSchema.Validate(value))
+}
+
+func TestRecursiveSchemaDetectsInstanceCycle(t *testing.T) {
+	var commentSchema Schema
+	commentSchema = Object(map[string]Schema{
+		"body":    String(),
+		"replies": Array(Recursive(func() Schema { return commentSchema })).Optional(),
+	})
+
+	cyclic := map[string]interface{}{"body": "self-referential"}
+	cyclic["replies"] = []interface{}{cyclic}
+
+	err := commentSchema.Validate(cyclic)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cyclic reference")
+}
+
+func TestDefaultCompilerRegisterCompileLazy(t *testing.T) {
+	Register("pkg-level-user", String().Min(1))
+
+	resolved, err := Compile("pkg-level-user")
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate("Ada"))
+
+	lazy := Lazy("pkg-level-user")
+	assert.NoError(t, lazy.Validate("Grace"))
+}
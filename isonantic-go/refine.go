@@ -0,0 +1,113 @@
+package isonantic
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefineContext is passed to an Object.SuperRefine function, letting user
+// code append ValidationErrors at arbitrary sub-paths rather than being
+// limited to a single error tied to the value under validation.
+type RefineContext struct {
+	errs *ValidationErrors
+}
+
+// AddError appends a validation error at path (e.g. "passwordConfirm").
+func (c *RefineContext) AddError(path string, message string) {
+	c.errs.Errors = append(c.errs.Errors, newValidationError(path, []string{path}, "", message, nil, nil))
+}
+
+// SuperRefine adds a cross-field validation function that sees the whole
+// object and can report errors against any of its fields, e.g. "password
+// must equal passwordConfirm" or "endDate must be after startDate".
+func (s *ObjectSchema) SuperRefine(fn func(obj map[string]interface{}, ctx *RefineContext) error) *ObjectSchema {
+	s.superRefinements = append(s.superRefinements, fn)
+	return s
+}
+
+// ContextValidator is implemented by schemas that support IO-capable
+// validation with cancellation and deadlines via ValidateContext.
+type ContextValidator interface {
+	ValidateContext(ctx context.Context, value interface{}) error
+}
+
+// ValidateContext validates value against s, using s's ValidateContext
+// implementation if it has one (so refinements can perform IO such as
+// database uniqueness checks), falling back to the ordinary Validate.
+func ValidateContext(ctx context.Context, s Schema, value interface{}) error {
+	if cv, ok := s.(ContextValidator); ok {
+		return cv.ValidateContext(ctx, value)
+	}
+	return s.Validate(value)
+}
+
+// ValidateContext validates value, honoring ctx cancellation and running
+// SuperRefine hooks (which may themselves perform IO, e.g. a database
+// uniqueness check on a Table's primary key).
+func (s *ObjectSchema) ValidateContext(ctx context.Context, value interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", value)
+	}
+
+	var errs ValidationErrors
+	for name, schema := range s.fields {
+		fieldValue := obj[name]
+		if fieldValue == nil && !schema.IsOptional() {
+			if def, hasDefault := schema.GetDefault(); hasDefault {
+				obj[name] = def
+				continue
+			}
+		}
+		if coercer, ok := schema.(Coercer); ok {
+			if coerced, err := coercer.CoerceValue(fieldValue); err == nil {
+				fieldValue = coerced
+				obj[name] = fieldValue
+			}
+		}
+		if err := ValidateContext(ctx, schema, fieldValue); err != nil {
+			code, params := classifyError(err)
+			errs.Errors = append(errs.Errors, newValidationError(name, []string{name}, code, err.Error(), fieldValue, params))
+			if !errs.keepGoing(s.settings) {
+				break
+			}
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	if err := s.runSuperRefinements(ctx, obj, &errs); err != nil {
+		return err
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return s.runRefinements(value)
+}
+
+func (s *ObjectSchema) runSuperRefinements(ctx context.Context, obj map[string]interface{}, errs *ValidationErrors) error {
+	rc := &RefineContext{errs: errs}
+	for _, fn := range s.superRefinements {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(obj, rc); err != nil {
+			errs.Errors = append(errs.Errors, newValidationError("", nil, "", err.Error(), nil, nil))
+		}
+	}
+	return nil
+}
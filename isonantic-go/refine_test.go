@@ -0,0 +1,93 @@
+package isonantic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperRefineCrossField(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"password":        String(),
+		"passwordConfirm": String(),
+	}).SuperRefine(func(obj map[string]interface{}, ctx *RefineContext) error {
+		if obj["password"] != obj["passwordConfirm"] {
+			ctx.AddError("passwordConfirm", "must equal password")
+		}
+		return nil
+	})
+
+	err := schema.Validate(map[string]interface{}{"password": "a", "passwordConfirm": "a"})
+	assert.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{"password": "a", "passwordConfirm": "b"})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Equal(t, "passwordConfirm", verrs.Errors[0].Field)
+}
+
+func TestValidateContextCancellation(t *testing.T) {
+	schema := Object(map[string]Schema{"name": String()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ValidateContext(ctx, schema, map[string]interface{}{"name": "a"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestValidateContextRunsSuperRefine(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"a": String(),
+	}).SuperRefine(func(obj map[string]interface{}, ctx *RefineContext) error {
+		ctx.AddError("a", "always fails")
+		return nil
+	})
+
+	err := ValidateContext(context.Background(), schema, map[string]interface{}{"a": "x"})
+	require.Error(t, err)
+}
+
+func TestTablePrimaryKeyViolation(t *testing.T) {
+	schema := Table("users", map[string]Schema{
+		"id": Int(),
+	}).PrimaryKey("id")
+
+	err := schema.Validate([]interface{}{
+		map[string]interface{}{"id": int64(1)},
+		map[string]interface{}{"id": int64(1)},
+	})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Equal(t, "table.primary_key_violation", verrs.Errors[0].Code)
+}
+
+func TestDocumentForeignKeyViolation(t *testing.T) {
+	usersSchema := Table("users", map[string]Schema{"id": Int()})
+	ordersSchema := Table("orders", map[string]Schema{
+		"id":      Int(),
+		"user_id": Int(),
+	}).ForeignKey("user_id", "users", "id")
+
+	doc := Document(map[string]Schema{
+		"users":  usersSchema,
+		"orders": ordersSchema,
+	})
+
+	_, err := doc.Parse(map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"id": int64(1)},
+		},
+		"orders": []interface{}{
+			map[string]interface{}{"id": int64(1), "user_id": int64(99)},
+		},
+	})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Equal(t, "table.foreign_key_violation", verrs.Errors[0].Code)
+}
@@ -0,0 +1,267 @@
+package isonantic
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct derives a Schema from a Go struct (or pointer to struct) using `ison` struct
+// tags, e.g. `ison:"name,min=1,email,optional"`. Nested structs become Object schemas,
+// slices become Array schemas, time.Time fields become a date-time formatted string,
+// and pointer fields are treated as optional.
+func Struct(v interface{}) Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return structSchema(t)
+}
+
+type isonTag struct {
+	name      string
+	optional  bool
+	email     bool
+	url       bool
+	omitempty bool
+	min       *int
+	max       *float64
+}
+
+func parseIsonTag(fieldName, tag string) isonTag {
+	parts := strings.Split(tag, ",")
+	result := isonTag{name: fieldName}
+	if len(parts) > 0 && parts[0] != "" && parts[0] != "-" {
+		result.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "optional":
+			result.optional = true
+		case opt == "email":
+			result.email = true
+		case opt == "url":
+			result.url = true
+		case opt == "omitempty":
+			result.omitempty = true
+		case strings.HasPrefix(opt, "min="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(opt, "min=")); err == nil {
+				result.min = &n
+			}
+		case strings.HasPrefix(opt, "max="):
+			if n, err := strconv.ParseFloat(strings.TrimPrefix(opt, "max="), 64); err == nil {
+				result.max = &n
+			}
+		}
+	}
+	return result
+}
+
+func structSchema(t reflect.Type) Schema {
+	fields := make(map[string]Schema)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		tagStr, hasTag := f.Tag.Lookup("ison")
+		if hasTag && tagStr == "-" {
+			continue
+		}
+		tag := parseIsonTag(toSnakeCase(f.Name), tagStr)
+		fieldSchema := fieldSchemaFor(f.Type, tag)
+		if tag.optional {
+			fieldSchema = markOptional(fieldSchema)
+		}
+		fields[tag.name] = fieldSchema
+	}
+	return Object(fields)
+}
+
+func fieldSchemaFor(t reflect.Type, tag isonTag) Schema {
+	if t.Kind() == reflect.Ptr {
+		return markOptional(fieldSchemaFor(t.Elem(), tag))
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return String().Format("date-time")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s := String()
+		if tag.min != nil {
+			s.Min(*tag.min)
+		}
+		if tag.max != nil {
+			s.Max(int(*tag.max))
+		}
+		if tag.email {
+			s.Email()
+		}
+		if tag.url {
+			s.URL()
+		}
+		return s
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := Int()
+		if tag.min != nil {
+			n.Min(float64(*tag.min))
+		}
+		if tag.max != nil {
+			n.Max(*tag.max)
+		}
+		return n
+
+	case reflect.Float32, reflect.Float64:
+		n := Number()
+		if tag.min != nil {
+			n.Min(float64(*tag.min))
+		}
+		if tag.max != nil {
+			n.Max(*tag.max)
+		}
+		return n
+
+	case reflect.Bool:
+		return Boolean()
+
+	case reflect.Struct:
+		return structSchema(t)
+
+	case reflect.Slice, reflect.Array:
+		return Array(fieldSchemaFor(t.Elem(), isonTag{}))
+
+	default:
+		return String()
+	}
+}
+
+func toSnakeCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToLower(sb.String())
+}
+
+// Bind validates data against s and decodes it into the Go value pointed to by out in
+// one call, so callers don't have to hand-write schemas that duplicate their Go types.
+func Bind(s Schema, data interface{}, out interface{}) error {
+	if err := s.Validate(data); err != nil {
+		return err
+	}
+	return decodeInto(data, out)
+}
+
+// Decode validates value against s and decodes it into the Go value
+// pointed to by out, honoring `ison:"field_name,omitempty"` struct tags.
+// Validate fills in any missing fields' GetDefault() values on value
+// in place first, so they're present for Decode to pick up.
+func (s *ObjectSchema) Decode(value interface{}, out interface{}) error {
+	return Bind(s, value, out)
+}
+
+// Decode validates value against s and decodes it into the Go value
+// pointed to by out, the Document equivalent of ObjectSchema.Decode.
+func (s *DocumentSchema) Decode(value map[string]interface{}, out interface{}) error {
+	parsed, err := s.Parse(value)
+	if err != nil {
+		return err
+	}
+	return decodeInto(parsed, out)
+}
+
+func decodeInto(data interface{}, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("isonantic: Bind requires a non-nil pointer, got %T", out)
+	}
+	return decodeValue(reflect.ValueOf(data), rv.Elem())
+}
+
+func decodeValue(src reflect.Value, dst reflect.Value) error {
+	if !src.IsValid() {
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := decodeValue(src, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+
+	case reflect.Struct:
+		m, ok := src.Interface().(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("isonantic: cannot decode %T into struct", src.Interface())
+		}
+		t := dst.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			tag := parseIsonTag(toSnakeCase(f.Name), f.Tag.Get("ison"))
+			val, ok := m[tag.name]
+			if !ok || val == nil {
+				continue
+			}
+			if err := decodeValue(reflect.ValueOf(val), dst.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		arr, ok := src.Interface().([]interface{})
+		if !ok {
+			return fmt.Errorf("isonantic: cannot decode %T into slice", src.Interface())
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+		for i, item := range arr {
+			if err := decodeValue(reflect.ValueOf(item), slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := src.Interface().(type) {
+		case float64:
+			dst.SetInt(int64(v))
+		case int64:
+			dst.SetInt(v)
+		case int:
+			dst.SetInt(int64(v))
+		default:
+			return fmt.Errorf("isonantic: cannot decode %T into int", v)
+		}
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		if v, ok := src.Interface().(float64); ok {
+			dst.SetFloat(v)
+			return nil
+		}
+		return fmt.Errorf("isonantic: cannot decode %T into float", src.Interface())
+
+	default:
+		srcVal := reflect.ValueOf(src.Interface())
+		if srcVal.Type().AssignableTo(dst.Type()) {
+			dst.Set(srcVal)
+			return nil
+		}
+		return fmt.Errorf("isonantic: cannot decode %T into %s", src.Interface(), dst.Type())
+	}
+}
@@ -0,0 +1,102 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	City string `ison:"city,min=1"`
+}
+
+type testUser struct {
+	Name    string      `ison:"name,min=1"`
+	Email   string      `ison:"email,email"`
+	Age     *int        `ison:"age,optional"`
+	Address testAddress `ison:"address"`
+	Tags    []string    `ison:"tags,optional"`
+}
+
+func TestStructDerivesSchema(t *testing.T) {
+	schema := Struct(testUser{})
+
+	err := schema.Validate(map[string]interface{}{
+		"name":    "Ada",
+		"email":   "ada@example.com",
+		"address": map[string]interface{}{"city": "London"},
+	})
+	assert.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{
+		"name":    "Ada",
+		"email":   "not-an-email",
+		"address": map[string]interface{}{"city": "London"},
+	})
+	assert.Error(t, err)
+}
+
+type testPost struct {
+	Title  string `ison:"title"`
+	Status string `ison:"status,omitempty"`
+}
+
+func TestObjectSchemaDecodeAppliesDefaults(t *testing.T) {
+	schema := Object(map[string]Schema{
+		"title":  String(),
+		"status": String().Default("draft"),
+	})
+
+	data := map[string]interface{}{"title": "Hello"}
+
+	var out testPost
+	require.NoError(t, schema.Decode(data, &out))
+	assert.Equal(t, "Hello", out.Title)
+	assert.Equal(t, "draft", out.Status)
+}
+
+type testUserRow struct {
+	ID   int64  `ison:"id"`
+	Name string `ison:"name"`
+}
+
+type testUsersDoc struct {
+	Users []testUserRow `ison:"users"`
+}
+
+func TestDocumentSchemaDecodesIntoStruct(t *testing.T) {
+	doc := Document(map[string]Schema{
+		"users": Table("users", map[string]Schema{
+			"id":   Int(),
+			"name": String(),
+		}),
+	})
+
+	data := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"id": int64(1), "name": "Ada"},
+		},
+	}
+
+	var out testUsersDoc
+	require.NoError(t, doc.Decode(data, &out))
+	require.Len(t, out.Users, 1)
+	assert.Equal(t, int64(1), out.Users[0].ID)
+	assert.Equal(t, "Ada", out.Users[0].Name)
+}
+
+func TestBindDecodesIntoStruct(t *testing.T) {
+	schema := Struct(testUser{})
+	data := map[string]interface{}{
+		"name":    "Ada",
+		"email":   "ada@example.com",
+		"address": map[string]interface{}{"city": "London"},
+	}
+
+	var out testUser
+	err := Bind(schema, data, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", out.Name)
+	assert.Equal(t, "London", out.Address.City)
+}
@@ -0,0 +1,275 @@
+package isonantic
+
+import "fmt"
+
+// UnionSchema validates a value against a set of branch schemas, succeeding
+// if any branch matches.
+type UnionSchema struct {
+	BaseSchema
+	schemas []Schema
+}
+
+// Union creates a schema that validates if any of the given schemas match.
+func Union(schemas ...Schema) *UnionSchema {
+	return &UnionSchema{schemas: schemas}
+}
+
+// Optional marks as optional
+func (s *UnionSchema) Optional() *UnionSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *UnionSchema) Describe(desc string) *UnionSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate validates the value against every branch, succeeding on the
+// first match. If every branch fails, it reports the best-matching
+// branch's errors (the one with the fewest), rather than concatenating all
+// branches' unrelated complaints into one unreadable message.
+func (s *UnionSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	if len(s.schemas) == 0 {
+		return fmt.Errorf("union has no branches to match")
+	}
+
+	var best ValidationErrors
+	haveBest := false
+	for i, branch := range s.schemas {
+		err := branch.Validate(value)
+		if err == nil {
+			return s.runRefinements(value)
+		}
+
+		var candidate ValidationErrors
+		if ve, ok := err.(ValidationErrors); ok {
+			candidate = ve
+		} else {
+			field := fmt.Sprintf("union[%d]", i)
+			candidate = ValidationErrors{Errors: []ValidationError{newValidationError(field, []string{field}, "", err.Error(), value, nil)}}
+		}
+
+		if !haveBest || len(candidate.Errors) < len(best.Errors) {
+			best = candidate
+			haveBest = true
+		}
+	}
+
+	return best
+}
+
+// DiscriminatedUnionSchema picks a branch schema by reading a tag field from
+// the input map, giving O(1) branch selection and a single focused error path.
+type DiscriminatedUnionSchema struct {
+	BaseSchema
+	key      string
+	variants map[string]Schema
+}
+
+// DiscriminatedUnion creates a schema that dispatches on key to select a variant.
+func DiscriminatedUnion(key string, variants map[string]Schema) *DiscriminatedUnionSchema {
+	return &DiscriminatedUnionSchema{key: key, variants: variants}
+}
+
+// Optional marks as optional
+func (s *DiscriminatedUnionSchema) Optional() *DiscriminatedUnionSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *DiscriminatedUnionSchema) Describe(desc string) *DiscriminatedUnionSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate selects a variant by the discriminator field and validates against it.
+func (s *DiscriminatedUnionSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", value)
+	}
+
+	tag, ok := obj[s.key].(string)
+	if !ok {
+		return fmt.Errorf("missing or non-string discriminator field %q", s.key)
+	}
+
+	variant, ok := s.variants[tag]
+	if !ok {
+		return fmt.Errorf("unknown discriminator value %q for field %q", tag, s.key)
+	}
+
+	if err := variant.Validate(value); err != nil {
+		return err
+	}
+
+	return s.runRefinements(value)
+}
+
+// TupleSchema validates a fixed-length, heterogeneous array, with an
+// optional Rest schema for any trailing items.
+type TupleSchema struct {
+	BaseSchema
+	items []Schema
+	rest  Schema
+}
+
+// Tuple creates a schema for a fixed-length array of distinct item schemas.
+func Tuple(items ...Schema) *TupleSchema {
+	return &TupleSchema{items: items}
+}
+
+// Rest allows trailing items beyond the fixed positions, each validated against schema.
+func (s *TupleSchema) Rest(schema Schema) *TupleSchema {
+	s.rest = schema
+	return s
+}
+
+// Optional marks as optional
+func (s *TupleSchema) Optional() *TupleSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *TupleSchema) Describe(desc string) *TupleSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate validates a tuple value.
+func (s *TupleSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	arr, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array, got %T", value)
+	}
+
+	if s.rest == nil && len(arr) != len(s.items) {
+		return fmt.Errorf("expected tuple of exactly %d items, got %d", len(s.items), len(arr))
+	}
+	if len(arr) < len(s.items) {
+		return fmt.Errorf("expected tuple of at least %d items, got %d", len(s.items), len(arr))
+	}
+
+	var errs ValidationErrors
+	for i, itemSchema := range s.items {
+		if err := itemSchema.Validate(arr[i]); err != nil {
+			errs.Errors = append(errs.Errors, ValidationError{
+				Field:   fmt.Sprintf("[%d]", i),
+				Path:    []string{fmt.Sprintf("%d", i)},
+				Message: NewMessage(err.Error(), "", nil),
+				Value:   arr[i],
+			})
+		}
+	}
+
+	for i := len(s.items); i < len(arr); i++ {
+		if s.rest == nil {
+			break
+		}
+		if err := s.rest.Validate(arr[i]); err != nil {
+			errs.Errors = append(errs.Errors, ValidationError{
+				Field:   fmt.Sprintf("[%d]", i),
+				Path:    []string{fmt.Sprintf("%d", i)},
+				Message: NewMessage(err.Error(), "", nil),
+				Value:   arr[i],
+			})
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return s.runRefinements(value)
+}
+
+// RecordSchema validates a map with both keys and values constrained by schemas.
+type RecordSchema struct {
+	BaseSchema
+	keySchema   Schema
+	valueSchema Schema
+}
+
+// Record creates a schema for a map with validated keys and values.
+func Record(keySchema, valueSchema Schema) *RecordSchema {
+	return &RecordSchema{keySchema: keySchema, valueSchema: valueSchema}
+}
+
+// Optional marks as optional
+func (s *RecordSchema) Optional() *RecordSchema {
+	s.setOptional()
+	return s
+}
+
+// Describe adds description
+func (s *RecordSchema) Describe(desc string) *RecordSchema {
+	s.setDescription(desc)
+	return s
+}
+
+// Validate validates a record (map) value.
+func (s *RecordSchema) Validate(value interface{}) error {
+	if value == nil {
+		if s.optional {
+			return nil
+		}
+		return fmt.Errorf("required field is missing")
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", value)
+	}
+
+	var errs ValidationErrors
+	for key, val := range obj {
+		if err := s.keySchema.Validate(key); err != nil {
+			errs.Errors = append(errs.Errors, ValidationError{
+				Field:   fmt.Sprintf("%s (key)", key),
+				Path:    []string{key},
+				Message: NewMessage(err.Error(), "", nil),
+				Value:   key,
+			})
+			continue
+		}
+		if err := s.valueSchema.Validate(val); err != nil {
+			errs.Errors = append(errs.Errors, ValidationError{
+				Field:   key,
+				Path:    []string{key},
+				Message: NewMessage(err.Error(), "", nil),
+				Value:   val,
+			})
+		}
+	}
+
+	if errs.HasErrors() {
+		return errs
+	}
+
+	return s.runRefinements(value)
+}
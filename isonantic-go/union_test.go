@@ -0,0 +1,69 @@
+package isonantic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnionMatchesAnyBranch(t *testing.T) {
+	schema := Union(String(), Int())
+
+	assert.NoError(t, schema.Validate("hello"))
+	assert.NoError(t, schema.Validate(int64(5)))
+	assert.Error(t, schema.Validate(true))
+}
+
+func TestDiscriminatedUnionDispatches(t *testing.T) {
+	schema := DiscriminatedUnion("kind", map[string]Schema{
+		"circle": Object(map[string]Schema{"kind": String(), "radius": Number()}),
+		"square": Object(map[string]Schema{"kind": String(), "side": Number()}),
+	})
+
+	err := schema.Validate(map[string]interface{}{"kind": "circle", "radius": 2.0})
+	assert.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{"kind": "triangle"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown discriminator")
+}
+
+func TestUnionReportsBestMatchingBranchErrors(t *testing.T) {
+	schema := Union(
+		Object(map[string]Schema{"a": String(), "b": String(), "c": String()}),
+		Object(map[string]Schema{"name": String()}),
+	)
+
+	err := schema.Validate(map[string]interface{}{"name": int64(1)})
+	require.Error(t, err)
+	verrs, ok := err.(ValidationErrors)
+	require.True(t, ok)
+	assert.Len(t, verrs.Errors, 1)
+}
+
+func TestTupleFixedLength(t *testing.T) {
+	schema := Tuple(String(), Int())
+
+	assert.NoError(t, schema.Validate([]interface{}{"a", int64(1)}))
+	assert.Error(t, schema.Validate([]interface{}{"a"}))
+	assert.Error(t, schema.Validate([]interface{}{"a", int64(1), int64(2)}))
+}
+
+func TestTupleWithRest(t *testing.T) {
+	schema := Tuple(String()).Rest(Int())
+
+	assert.NoError(t, schema.Validate([]interface{}{"a", int64(1), int64(2)}))
+	err := schema.Validate([]interface{}{"a", "not an int"})
+	require.Error(t, err)
+}
+
+func TestRecordValidatesKeysAndValues(t *testing.T) {
+	schema := Record(String().Min(1), Int())
+
+	err := schema.Validate(map[string]interface{}{"a": int64(1), "b": int64(2)})
+	assert.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{"a": "not an int"})
+	assert.Error(t, err)
+}